@@ -93,6 +93,7 @@ func New(config *config.Config, db *storage.DB, bundle *i18n.Bundle) *ComedianAP
 	echo.POST("/event", api.handleEvent)
 	echo.POST("/service-message", api.handleServiceMessage)
 	echo.POST("/commands", api.handleCommands)
+	echo.POST("/interactions", api.handleInteractions)
 	echo.POST("/team-worklogs", api.showTeamWorklogs)
 	echo.POST("/user-commands", api.handleUsersCommands)
 	echo.GET("/auth", api.auth)
@@ -104,17 +105,49 @@ func New(config *config.Config, db *storage.DB, bundle *i18n.Bundle) *ComedianAP
 	g.PATCH("/bots/:id", api.updateBot)
 
 	g.GET("/standups", api.listStandups)
+	g.POST("/standups", api.backfillStandup)
 	g.GET("/standups/:id", api.getStandup)
 	g.PATCH("/standups/:id", api.updateStandup)
 	g.DELETE("/standups/:id", api.deleteStandup)
+	g.GET("/standups/:id/revisions", api.listStandupRevisions)
 
 	g.GET("/channels", api.listChannels)
 	g.PATCH("/channels/:id", api.updateChannel)
 	g.DELETE("/channels/:id", api.deleteChannel)
+	g.GET("/channels/:id/escalation_policy", api.getEscalationPolicy)
+	g.PUT("/channels/:id/escalation_policy", api.updateEscalationPolicy)
+	g.GET("/channels/:id/holidays", api.listHolidays)
+	g.POST("/channels/:id/holidays", api.createHoliday)
+	g.POST("/channels/:id/holidays/import", api.importHolidays)
+	g.DELETE("/holidays/:id", api.deleteHoliday)
+
+	g.GET("/channels/:id/check_ins", api.listCheckIns)
+	g.POST("/channels/:id/check_ins", api.createCheckIn)
+	g.PATCH("/check_ins/:id", api.updateCheckIn)
+	g.DELETE("/check_ins/:id", api.deleteCheckIn)
+
+	g.GET("/blockers", api.listBlockers)
+	g.PATCH("/blockers/:id", api.updateBlocker)
 
 	g.GET("/standupers", api.listStandupers)
 	g.PATCH("/standupers/:id", api.updateStanduper)
 	g.DELETE("/standupers/:id", api.deleteStanduper)
+	g.GET("/standupers/:id/punctuality", api.getStanduperPunctuality)
+
+	g.GET("/notification_preferences", api.listNotificationPreferences)
+	g.POST("/notification_preferences", api.createNotificationPreference)
+	g.PATCH("/notification_preferences/:id", api.updateNotificationPreference)
+	g.DELETE("/notification_preferences/:id", api.deleteNotificationPreference)
+
+	g.GET("/absences", api.listAbsences)
+	g.POST("/absences", api.createAbsence)
+	g.PATCH("/absences/:id", api.updateAbsence)
+	g.DELETE("/absences/:id", api.deleteAbsence)
+
+	g.GET("/jobs", api.listJobs)
+
+	g.GET("/reports", api.listReports)
+	g.GET("/rollups", api.listRollups)
 
 	return &api
 }
@@ -315,6 +348,41 @@ func (api *ComedianAPI) handleCommands(c echo.Context) error {
 	return c.String(http.StatusOK, message)
 }
 
+func (api *ComedianAPI) handleInteractions(c echo.Context) error {
+	var submission botuser.ViewSubmission
+
+	err := json.Unmarshal([]byte(c.FormValue("payload")), &submission)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if submission.Token != api.config.SlackVerificationToken {
+		return echo.NewHTTPError(http.StatusBadRequest, "wrong verification token")
+	}
+
+	if submission.Type != "view_submission" {
+		return c.NoContent(http.StatusOK)
+	}
+
+	bot, err := api.SelectBot(submission.Team.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = bot.HandleViewSubmission(submission)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":    err,
+			"function": "handle view submission",
+			"user":     submission.User.ID},
+		).Error("handleInteractions failed")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	//empty response closes the modal
+	return c.NoContent(http.StatusOK)
+}
+
 func (api *ComedianAPI) handleUsersCommands(c echo.Context) error {
 	slashCommand, err := slack.SlashCommandParse(c.Request())
 	if err != nil {
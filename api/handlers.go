@@ -1,10 +1,13 @@
 package api
 
 import (
+	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo"
+	"github.com/maddevsio/comedian/model"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -126,6 +129,35 @@ func (api *ComedianAPI) listStandups(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"standups": standups})
 }
 
+func (api *ComedianAPI) backfillStandup(c echo.Context) error {
+	var payload struct {
+		model.Standup
+		Date string `json:"date"`
+	}
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+
+	bot, err := api.SelectBot(c.Get("teamID").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	standup, err := bot.BackfillStandup(model.Standup{
+		ChannelID: payload.ChannelID,
+		UserID:    payload.UserID,
+		Comment:   payload.Comment,
+		Done:      payload.Done,
+		Planned:   payload.Planned,
+		Blockers:  payload.Blockers,
+	}, payload.Date)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{"standup": standup})
+}
+
 func (api *ComedianAPI) updateStandup(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
 	if err != nil {
@@ -137,6 +169,8 @@ func (api *ComedianAPI) updateStandup(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
 	}
 
+	revision := standup.Revision(model.RevisionEdited)
+
 	if err := c.Bind(&standup); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
 	}
@@ -150,9 +184,37 @@ func (api *ComedianAPI) updateStandup(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	_, err = api.db.CreateStandupRevision(revision)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{"standup": standup})
 }
 
+func (api *ComedianAPI) listStandupRevisions(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	standup, err := api.db.GetStandup(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if standup.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	revisions, err := api.db.ListStandupRevisions(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"revisions": revisions})
+}
+
 func (api *ComedianAPI) deleteStandup(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
 	if err != nil {
@@ -176,6 +238,58 @@ func (api *ComedianAPI) deleteStandup(c echo.Context) error {
 	return c.JSON(http.StatusNoContent, "")
 }
 
+func (api *ComedianAPI) listBlockers(c echo.Context) error {
+	blockers, err := api.db.ListWorkspaceBlockers(c.Get("teamID").(string), c.QueryParam("status"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"blockers": blockers})
+}
+
+func (api *ComedianAPI) updateBlocker(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	blocker, err := api.db.GetBlocker(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if blocker.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	var payload struct {
+		Status string `json:"status"`
+		UserID string `json:"user_id"`
+	}
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+
+	switch payload.Status {
+	case model.BlockerAcknowledged:
+		err = blocker.Acknowledge(payload.UserID, time.Now().Unix())
+	case model.BlockerResolved:
+		err = blocker.Resolve(payload.UserID, time.Now().Unix())
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	blocker, err = api.db.UpdateBlocker(blocker)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"blocker": blocker})
+}
+
 func (api *ComedianAPI) listChannels(c echo.Context) error {
 
 	channels, err := api.db.ListWorkspaceProjects(c.Get("teamID").(string))
@@ -197,6 +311,7 @@ func (api *ComedianAPI) updateChannel(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
 	}
 
+	submissionDays := channel.SubmissionDays
 	if err := c.Bind(&channel); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
 	}
@@ -205,6 +320,13 @@ func (api *ComedianAPI) updateChannel(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
 	}
 
+	//submission days stored before schedules were parsed are kept as they are until changed
+	if channel.SubmissionDays != submissionDays {
+		if _, err := model.ParseSchedule(channel.SubmissionDays); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
 	channel, err = api.db.UpdateProject(channel)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
@@ -213,6 +335,301 @@ func (api *ComedianAPI) updateChannel(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"channel": channel})
 }
 
+func (api *ComedianAPI) getEscalationPolicy(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	bot, err := api.SelectBot(channel.WorkspaceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"escalation_policy": bot.ProjectEscalationPolicy(channel),
+		"default":           len(channel.EscalationPolicy) == 0,
+	})
+}
+
+func (api *ComedianAPI) updateEscalationPolicy(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	var policy model.EscalationPolicy
+	if err := c.Bind(&policy); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	channel.EscalationPolicy = policy
+
+	channel, err = api.db.UpdateProject(channel)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"escalation_policy": channel.EscalationPolicy})
+}
+
+func (api *ComedianAPI) listHolidays(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"holidays": channel.Holidays})
+}
+
+func (api *ComedianAPI) createHoliday(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	holiday := model.Holiday{}
+	if err := c.Bind(&holiday); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	holiday.CreatedAt = time.Now().Unix()
+	holiday.WorkspaceID = channel.WorkspaceID
+	holiday.ChannelID = channel.ChannelID
+
+	holiday, err = api.db.CreateHoliday(holiday)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{"holiday": holiday})
+}
+
+//importHolidays adds holidays from ICS file sent as "calendar" form file or as request body.
+//Days that are already in the project calendar are skipped
+func (api *ComedianAPI) importHolidays(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	data, err := readCalendar(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+
+	holidays, err := model.ParseICS(data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	imported := []model.Holiday{}
+	for _, holiday := range holidays {
+		if channel.IsHoliday(holiday.Date) {
+			continue
+		}
+		holiday.CreatedAt = time.Now().Unix()
+		holiday.WorkspaceID = channel.WorkspaceID
+		holiday.ChannelID = channel.ChannelID
+
+		holiday, err = api.db.CreateHoliday(holiday)
+		if err != nil {
+			log.Error("CreateHoliday failed: ", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+		}
+		channel.Holidays = append(channel.Holidays, holiday)
+		imported = append(imported, holiday)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{"holidays": imported, "skipped": len(holidays) - len(imported)})
+}
+
+func readCalendar(c echo.Context) (string, error) {
+	file, err := c.FormFile("calendar")
+	if err != nil {
+		data, err := ioutil.ReadAll(c.Request().Body)
+		return string(data), err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	data, err := ioutil.ReadAll(src)
+	return string(data), err
+}
+
+func (api *ComedianAPI) deleteHoliday(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	holiday, err := api.db.GetHoliday(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if holiday.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	err = api.db.DeleteHoliday(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusNoContent, "")
+}
+
+func (api *ComedianAPI) listCheckIns(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"check_ins": channel.CheckIns})
+}
+
+func (api *ComedianAPI) createCheckIn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	channel, err := api.db.GetProject(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if channel.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	checkIn := model.CheckIn{}
+	if err := c.Bind(&checkIn); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	checkIn.CreatedAt = time.Now().Unix()
+	checkIn.WorkspaceID = channel.WorkspaceID
+	checkIn.ChannelID = channel.ChannelID
+
+	checkIn, err = api.db.CreateCheckIn(checkIn)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{"check_in": checkIn})
+}
+
+func (api *ComedianAPI) updateCheckIn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	checkIn, err := api.db.GetCheckIn(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if checkIn.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	stored := checkIn
+	if err := c.Bind(&checkIn); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	checkIn.ID = stored.ID
+	checkIn.WorkspaceID = stored.WorkspaceID
+	checkIn.ChannelID = stored.ChannelID
+
+	checkIn, err = api.db.UpdateCheckIn(checkIn)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"check_in": checkIn})
+}
+
+func (api *ComedianAPI) deleteCheckIn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	checkIn, err := api.db.GetCheckIn(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if checkIn.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	err = api.db.DeleteCheckIn(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusNoContent, "")
+}
+
 func (api *ComedianAPI) deleteChannel(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
 	if err != nil {
@@ -273,6 +690,46 @@ func (api *ComedianAPI) updateStanduper(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"standuper": standuper})
 }
 
+func (api *ComedianAPI) getStanduperPunctuality(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	standuper, err := api.db.GetStanduper(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if standuper.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	standups, err := api.db.ListStanduperStandups(standuper.UserID, standuper.ChannelID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	channel, err := api.db.SelectProject(standuper.ChannelID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	punctuality := model.Punctuality{
+		UserID:    standuper.UserID,
+		ChannelID: standuper.ChannelID,
+	}
+	for _, standup := range standups {
+		//standups submitted on holidays are not expected, so they do not count
+		if channel.IsHoliday(standup.StandupDate) {
+			continue
+		}
+		punctuality.Add(standup)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"punctuality": punctuality, "standups": standups})
+}
+
 func (api *ComedianAPI) deleteStanduper(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
 	if err != nil {
@@ -295,3 +752,225 @@ func (api *ComedianAPI) deleteStanduper(c echo.Context) error {
 
 	return c.JSON(http.StatusNoContent, "")
 }
+
+func (api *ComedianAPI) listNotificationPreferences(c echo.Context) error {
+	preferences, err := api.db.ListNotificationPreferences(c.Get("teamID").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"notification_preferences": preferences})
+}
+
+func (api *ComedianAPI) createNotificationPreference(c echo.Context) error {
+	preference := model.NotificationPreference{Delivery: model.DeliveryChannel}
+	if err := c.Bind(&preference); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	preference.CreatedAt = time.Now().Unix()
+	preference.WorkspaceID = c.Get("teamID").(string)
+
+	preference, err := api.db.CreateNotificationPreference(preference)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{"notification_preference": preference})
+}
+
+func (api *ComedianAPI) updateNotificationPreference(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	preference, err := api.db.GetNotificationPreference(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if preference.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	stored := preference
+	if err := c.Bind(&preference); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	preference.ID = stored.ID
+	preference.WorkspaceID = stored.WorkspaceID
+	preference.UserID = stored.UserID
+
+	preference, err = api.db.UpdateNotificationPreference(preference)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"notification_preference": preference})
+}
+
+func (api *ComedianAPI) deleteNotificationPreference(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	preference, err := api.db.GetNotificationPreference(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if preference.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	err = api.db.DeleteNotificationPreference(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusNoContent, "")
+}
+
+func (api *ComedianAPI) listAbsences(c echo.Context) error {
+	absences, err := api.db.ListAbsences(c.Get("teamID").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	userID := c.QueryParam("user_id")
+	if userID != "" {
+		filtered := []model.Absence{}
+		for _, absence := range absences {
+			if absence.UserID == userID {
+				filtered = append(filtered, absence)
+			}
+		}
+		absences = filtered
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"absences": absences})
+}
+
+func (api *ComedianAPI) listJobs(c echo.Context) error {
+	jobs, err := api.db.ListPendingJobs(c.Get("teamID").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"jobs": jobs})
+}
+
+func (api *ComedianAPI) listReports(c echo.Context) error {
+	//like /report command, the last seven days are reported on by default
+	now := time.Now().UTC()
+	from, to := c.QueryParam("from"), c.QueryParam("to")
+	if from == "" {
+		from = now.AddDate(0, 0, -7).Format("2006-01-02")
+	}
+	if to == "" {
+		to = now.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	bot, err := api.SelectBot(c.Get("teamID").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	reports, err := bot.TeamReport(c.QueryParam("channel_id"), from, to)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"reports": reports})
+}
+
+func (api *ComedianAPI) listRollups(c echo.Context) error {
+	//the last finished month is summed up by default
+	period := c.QueryParam("period")
+	if period == "" {
+		period = model.RollupMonth
+	}
+
+	bot, err := api.SelectBot(c.Get("teamID").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	rollups, err := bot.Rollups(c.QueryParam("channel_id"), period, c.QueryParam("date"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"rollups": rollups})
+}
+
+func (api *ComedianAPI) createAbsence(c echo.Context) error {
+	absence := model.Absence{}
+	if err := c.Bind(&absence); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	absence.CreatedAt = time.Now().Unix()
+	absence.WorkspaceID = c.Get("teamID").(string)
+
+	absence, err := api.db.CreateAbsence(absence)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{"absence": absence})
+}
+
+func (api *ComedianAPI) updateAbsence(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	absence, err := api.db.GetAbsence(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if absence.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	stored := absence
+	if err := c.Bind(&absence); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectDataFormat)
+	}
+	absence.ID = stored.ID
+	absence.WorkspaceID = stored.WorkspaceID
+	absence.UserID = stored.UserID
+
+	absence, err = api.db.UpdateAbsence(absence)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"absence": absence})
+}
+
+func (api *ComedianAPI) deleteAbsence(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, incorrectID)
+	}
+
+	absence, err := api.db.GetAbsence(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, doesNotExist)
+	}
+
+	if absence.WorkspaceID != c.Get("teamID") {
+		return echo.NewHTTPError(http.StatusUnauthorized, accessDenied)
+	}
+
+	err = api.db.DeleteAbsence(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, somethingWentWrong)
+	}
+
+	return c.JSON(http.StatusNoContent, "")
+}
@@ -0,0 +1,192 @@
+package botuser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//onLeave tells if user is out of office on the date in YYYY-MM-DD format
+func (bot *Bot) onLeave(userID, date string) bool {
+	_, err := bot.db.SelectAbsence(bot.workspace.WorkspaceID, userID, date)
+	return err == nil
+}
+
+//absentDays counts project submission days from the first date to the last one
+//when the user is out of office
+func absentDays(project model.Project, absences []model.Absence, from, to time.Time) int {
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !shouldSubmitStandupIn(&project, day) {
+			continue
+		}
+		if absentOn(absences, day.Format("2006-01-02")) {
+			days++
+		}
+	}
+	return days
+}
+
+//absentOn tells if any of the absences covers the date
+func absentOn(absences []model.Absence, date string) bool {
+	for _, absence := range absences {
+		if absence.Covers(date) {
+			return true
+		}
+	}
+	return false
+}
+
+//outOfOffice handles /ooo command: "/ooo from to [reason]" adds absence,
+//"/ooo cancel ID" removes it and "/ooo" lists the upcoming ones
+func (bot *Bot) outOfOffice(command slack.SlashCommand) string {
+	args := strings.Fields(command.Text)
+	today := time.Now().Format("2006-01-02")
+
+	switch {
+	case len(args) == 0:
+		return bot.listAbsences(command.UserID, today)
+	case len(args) == 2 && args[0] == "cancel":
+		return bot.cancelAbsence(command.UserID, args[1])
+	case len(args) < 2:
+		return bot.wrongOutOfOffice()
+	}
+
+	absence := model.Absence{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: bot.workspace.WorkspaceID,
+		UserID:      command.UserID,
+		DateFrom:    args[0],
+		DateTo:      args[1],
+		Reason:      strings.Join(args[2:], " "),
+	}
+	if absence.Validate() != nil {
+		return bot.wrongOutOfOffice()
+	}
+
+	absence, err := bot.db.CreateAbsence(absence)
+	if err != nil {
+		log.Error("CreateAbsence failed: ", err)
+		failedAbsence, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "failedAbsence",
+				Other: "Could not save your absence",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return failedAbsence
+	}
+
+	absenceCreated, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "absenceCreated",
+			Other: "You are out of office from {{.From}} to {{.To}}. Nobody will remind you about standups these days",
+		},
+		TemplateData: map[string]interface{}{
+			"From": absence.DateFrom,
+			"To":   absence.DateTo,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return absenceCreated
+}
+
+func (bot *Bot) listAbsences(userID, today string) string {
+	absences, err := bot.db.ListUserAbsences(bot.workspace.WorkspaceID, userID, today)
+	if err != nil || len(absences) == 0 {
+		noAbsences, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "noAbsences",
+				Other: "You have no upcoming absences. Use `/ooo YYYY-MM-DD YYYY-MM-DD reason` to add one",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return noAbsences
+	}
+
+	list := []string{}
+	for _, absence := range absences {
+		list = append(list, strings.TrimSpace(fmt.Sprintf("#%v %v - %v %v", absence.ID, absence.DateFrom, absence.DateTo, absence.Reason)))
+	}
+
+	showAbsences, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "showAbsences",
+			Other: "Your absences:\n{{.Absences}}\nUse `/ooo cancel ID` to remove one",
+		},
+		TemplateData: map[string]interface{}{
+			"Absences": strings.Join(list, "\n"),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return showAbsences
+}
+
+func (bot *Bot) cancelAbsence(userID, arg string) string {
+	id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		return bot.wrongOutOfOffice()
+	}
+
+	absence, err := bot.db.GetAbsence(id)
+	if err != nil || absence.WorkspaceID != bot.workspace.WorkspaceID || absence.UserID != userID {
+		return bot.wrongOutOfOffice()
+	}
+
+	err = bot.db.DeleteAbsence(id)
+	if err != nil {
+		log.Error("DeleteAbsence failed: ", err)
+		failedAbsence, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "failedAbsence",
+				Other: "Could not save your absence",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return failedAbsence
+	}
+
+	absenceCanceled, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "absenceCanceled",
+			Other: "Absence from {{.From}} to {{.To}} is canceled",
+		},
+		TemplateData: map[string]interface{}{
+			"From": absence.DateFrom,
+			"To":   absence.DateTo,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return absenceCanceled
+}
+
+func (bot *Bot) wrongOutOfOffice() string {
+	wrongOutOfOffice, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "wrongOutOfOffice",
+			Other: "Use `/ooo YYYY-MM-DD YYYY-MM-DD reason` to tell you are out of office, `/ooo` to list your absences and `/ooo cancel ID` to remove one",
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return wrongOutOfOffice
+}
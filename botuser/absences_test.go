@@ -0,0 +1,34 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbsentDays(t *testing.T) {
+	project := model.Project{
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+	}
+
+	//2019-09-02 is monday
+	weekStart := time.Date(2019, 9, 2, 0, 0, 0, 0, time.UTC)
+	weekEnd := time.Date(2019, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		absences []model.Absence
+		days     int
+	}{
+		{[]model.Absence{}, 0},
+		{[]model.Absence{{DateFrom: "2019-08-26", DateTo: "2019-08-30"}}, 0},
+		{[]model.Absence{{DateFrom: "2019-09-03", DateTo: "2019-09-04"}}, 2},
+		{[]model.Absence{{DateFrom: "2019-09-05", DateTo: "2019-09-15"}}, 2},
+		{[]model.Absence{{DateFrom: "2019-08-30", DateTo: "2019-09-20"}}, 5},
+		{[]model.Absence{{DateFrom: "2019-09-02", DateTo: "2019-09-03"}, {DateFrom: "2019-09-03", DateTo: "2019-09-04"}}, 3},
+	}
+	for _, tt := range testCases {
+		assert.Equal(t, tt.days, absentDays(project, tt.absences, weekStart, weekEnd))
+	}
+}
@@ -0,0 +1,177 @@
+package botuser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//BackfillStandup saves standup of the user for one of the past submission days.
+//Date is in YYYY-MM-DD format and is taken in project timezone. Sections are
+//parsed from the comment unless they are given
+func (bot *Bot) BackfillStandup(standup model.Standup, date string) (model.Standup, error) {
+	project, err := bot.db.SelectProject(standup.ChannelID)
+	if err != nil || project.WorkspaceID != bot.workspace.WorkspaceID {
+		return standup, errors.New("channel is not tracked by comedian")
+	}
+
+	_, err = bot.db.FindStansuperByUserID(standup.UserID, standup.ChannelID)
+	if err != nil {
+		return standup, errors.New("user does not submit standups in the channel")
+	}
+
+	day, err := backfillDay(project, date, time.Now())
+	if err != nil {
+		return standup, err
+	}
+
+	//standup answers the first check-in of the day the user missed
+	answered := bot.answeredCheckIns(standup.UserID, standup.ChannelID, day.Format("2006-01-02"))
+	checkIn := chooseCheckIn(project, answered, day)
+	if answered[checkIn.CheckInName] {
+		return standup, fmt.Errorf("standup for %v already exists", date)
+	}
+	standup.CheckIn = checkIn.CheckInName
+
+	if standup.Done == "" && standup.Planned == "" && standup.Blockers == "" {
+		bot.fillSections(&standup, project)
+	}
+
+	//standup is attributed to the middle of the day so that it stays within the day in reports
+	standup.CreatedAt = day.Add(12 * time.Hour).Unix()
+	standup.BackfilledAt = time.Now().Unix()
+	standup.StandupDate = day.Format("2006-01-02")
+	standup.WorkspaceID = bot.workspace.WorkspaceID
+	standup.DeadlineAt = 0
+	standup.LateMinutes = 0
+
+	summary, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "backfilledStandupSummary",
+			Other: "<@{{.User}}> standup for {{.Date}}, submitted later:\n*Done:* {{.Done}}\n*Planned:* {{.Planned}}\n*Blockers:* {{.Blockers}}",
+		},
+		TemplateData: map[string]interface{}{
+			"User":     standup.UserID,
+			"Date":     date,
+			"Done":     standup.Done,
+			"Planned":  standup.Planned,
+			"Blockers": standup.Blockers,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	ts, err := bot.postMessage(standup.ChannelID, summary, "", nil)
+	if err != nil {
+		return standup, err
+	}
+	standup.MessageTS = ts
+	if standup.Comment == "" {
+		standup.Comment = summary
+	}
+
+	standup, err = bot.db.CreateStandup(standup)
+	if err != nil {
+		return standup, err
+	}
+
+	err = bot.trackBlockers(standup)
+	if err != nil {
+		log.Error("trackBlockers failed: ", err)
+	}
+	return standup, nil
+}
+
+//backfillDay returns beginning of the day standup is backfilled for in project timezone.
+//Only past submission days can be backfilled
+func backfillDay(project model.Project, date string, now time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return day, fmt.Errorf("wrong date %v, use YYYY-MM-DD format", date)
+	}
+
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if !day.Before(today) {
+		return day, errors.New("standups can be backfilled only for past days")
+	}
+
+	if !shouldSubmitStandupIn(&project, day) {
+		return day, fmt.Errorf("%v is not a submission day", date)
+	}
+
+	return day, nil
+}
+
+//standupFor handles /standup_for command: first word is the date, the rest is the standup
+func (bot *Bot) standupFor(command slack.SlashCommand) string {
+	parts := strings.SplitN(strings.TrimSpace(command.Text), " ", 2)
+	if len(parts) < 2 || strings.TrimSpace(parts[1]) == "" {
+		wrongStandupFor, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "wrongStandupFor",
+				Other: "Use `/standup_for YYYY-MM-DD standup text` to submit standup for a past day",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return wrongStandupFor
+	}
+	date, text := parts[0], strings.TrimSpace(parts[1])
+
+	project, err := bot.db.SelectProject(command.ChannelID)
+	if err == nil {
+		problem := bot.analizeStandup(text, project)
+		if problem != "" {
+			return problem
+		}
+	}
+
+	_, err = bot.BackfillStandup(model.Standup{
+		ChannelID: command.ChannelID,
+		UserID:    command.UserID,
+		Comment:   text,
+	}, date)
+	if err != nil {
+		failedBackfillStandup, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "failedBackfillStandup",
+				Other: "Could not save standup: {{.Error}}",
+			},
+			TemplateData: map[string]interface{}{
+				"Error": err,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return failedBackfillStandup
+	}
+
+	standupBackfilled, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "standupBackfilled",
+			Other: "Standup for {{.Date}} is saved",
+		},
+		TemplateData: map[string]interface{}{
+			"Date": date,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return standupBackfilled
+}
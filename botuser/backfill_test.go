@@ -0,0 +1,71 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillDay(t *testing.T) {
+	project := model.Project{
+		TZ:             "Asia/Bishkek",
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+	}
+	loc, err := time.LoadLocation(project.TZ)
+	assert.NoError(t, err)
+
+	//2019-09-04 is wednesday, 2019-09-01 is sunday
+	now := time.Date(2019, 9, 4, 10, 0, 0, 0, loc)
+
+	testCases := []struct {
+		date         string
+		day          time.Time
+		errorMessage string
+	}{
+		{"2019-09-03", time.Date(2019, 9, 3, 0, 0, 0, 0, loc), ""},
+		{"2019-09-02", time.Date(2019, 9, 2, 0, 0, 0, 0, loc), ""},
+		{"2019-09-01", time.Time{}, "2019-09-01 is not a submission day"},
+		{"2019-09-04", time.Time{}, "standups can be backfilled only for past days"},
+		{"2019-09-05", time.Time{}, "standups can be backfilled only for past days"},
+		{"yesterday", time.Time{}, "wrong date yesterday, use YYYY-MM-DD format"},
+	}
+	for _, tt := range testCases {
+		day, err := backfillDay(project, tt.date, now)
+		if tt.errorMessage != "" {
+			assert.EqualError(t, err, tt.errorMessage)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.day.Unix(), day.Unix())
+	}
+}
+
+func TestBackfillStandupOutsideWorkspace(t *testing.T) {
+	project, err := bot.db.CreateProject(model.Project{
+		WorkspaceID: "otherTeam",
+		ChannelID:   "OTHER123",
+		ChannelName: "otherTeamChannel",
+		TZ:          "Asia/Bishkek",
+	})
+	assert.NoError(t, err)
+
+	standuper, err := bot.db.CreateStanduper(model.Standuper{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "otherTeam",
+		UserID:      "USER123",
+		ChannelID:   "OTHER123",
+	})
+	assert.NoError(t, err)
+
+	_, err = bot.BackfillStandup(model.Standup{
+		UserID:    "USER123",
+		ChannelID: "OTHER123",
+		Comment:   "yesterday, today, issues",
+	}, "2019-09-03")
+	assert.EqualError(t, err, "channel is not tracked by comedian")
+
+	assert.NoError(t, bot.db.DeleteStanduper(standuper.ID))
+	assert.NoError(t, bot.db.DeleteProject(project.ID))
+}
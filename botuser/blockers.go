@@ -0,0 +1,362 @@
+package botuser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//noBlockerAnswers are the ways people say that nothing blocks them
+var noBlockerAnswers = []string{
+	"no", "none", "nothing", "nope", "n/a", "no issues", "no blockers", "no problems", "nothing blocks me",
+	"нет", "ничего", "ничего не мешает", "проблем нет", "нет проблем", "не мешает",
+}
+
+//trackBlockers creates blockers mentioned in the latest standup of the user, carries
+//over the ones mentioned again and resolves the ones that are no longer mentioned
+func (bot *Bot) trackBlockers(standup model.Standup) error {
+	latest, err := bot.db.SelectLatestStandupByUser(standup.UserID, standup.ChannelID)
+	if err != nil || latest.ID != standup.ID {
+		//edits of older standups do not change current blockers
+		return nil
+	}
+
+	unresolved, err := bot.db.ListUserUnresolvedBlockers(standup.UserID, standup.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	mentioned := map[int64]bool{}
+
+	for _, item := range splitBlockers(standup.Blockers) {
+		carried := false
+		for _, blocker := range unresolved {
+			if mentioned[blocker.ID] || !sameBlocker(blocker.Text, item) {
+				continue
+			}
+			mentioned[blocker.ID] = true
+			carried = true
+
+			blocker.LastStandupID = standup.ID
+			blocker.MentionedAt = now
+			_, err = bot.db.UpdateBlocker(blocker)
+			if err != nil {
+				return err
+			}
+			break
+		}
+		if carried {
+			continue
+		}
+
+		_, err = bot.db.CreateBlocker(model.Blocker{
+			CreatedAt:     now,
+			WorkspaceID:   standup.WorkspaceID,
+			ChannelID:     standup.ChannelID,
+			UserID:        standup.UserID,
+			StandupID:     standup.ID,
+			LastStandupID: standup.ID,
+			Text:          item,
+			Status:        model.BlockerOpen,
+			MentionedAt:   now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, blocker := range unresolved {
+		if mentioned[blocker.ID] {
+			continue
+		}
+		err = blocker.Resolve("", now)
+		if err != nil {
+			continue
+		}
+		_, err = bot.db.UpdateBlocker(blocker)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//splitBlockers splits blockers section into separate issues, one per line
+func splitBlockers(text string) []string {
+	items := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimLeft(line, " \t*-•")
+		line = strings.TrimRight(line, " \t.,;!")
+		if line == "" || isNoBlockerAnswer(line) {
+			continue
+		}
+		items = append(items, line)
+	}
+	return items
+}
+
+func isNoBlockerAnswer(text string) bool {
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, answer := range noBlockerAnswers {
+		if text == answer {
+			return true
+		}
+	}
+	return false
+}
+
+//sameBlocker tells whether two texts describe the same issue: one contains
+//the other or at least a half of the words of the shorter one are in the longer one
+func sameBlocker(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if strings.Contains(a, b) || strings.Contains(b, a) {
+		return true
+	}
+
+	wordsA, wordsB := blockerWords(a), blockerWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return false
+	}
+
+	common := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			common++
+		}
+	}
+	shorter := len(wordsA)
+	if len(wordsB) < shorter {
+		shorter = len(wordsB)
+	}
+	return common*2 >= shorter
+}
+
+//blockerWords returns set of meaningful words, short ones like "is", "for" are skipped
+func blockerWords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) > 3 {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+//workingDaysBetween counts project submission days after from and up to to
+func workingDaysBetween(project model.Project, from, to time.Time) int {
+	days := 0
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()).AddDate(0, 0, 1)
+	for !day.After(to) {
+		if shouldSubmitStandupIn(&project, day) {
+			days++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return days
+}
+
+//escalateBlockers notifies project managers about blockers that stay open too long
+func (bot *Bot) escalateBlockers() error {
+	projects, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if project.BlockerEscalationDays <= 0 {
+			continue
+		}
+
+		loc, err := time.LoadLocation(project.TZ)
+		if err != nil {
+			log.Error("escalateBlockers LoadLocation failed: ", err)
+			continue
+		}
+		now := time.Now().In(loc)
+
+		blockers, err := bot.db.ListProjectUnresolvedBlockers(project.ChannelID)
+		if err != nil {
+			log.Error("ListProjectUnresolvedBlockers failed: ", err)
+			continue
+		}
+
+		for _, blocker := range blockers {
+			if blocker.Status != model.BlockerOpen || blocker.EscalatedAt != 0 {
+				continue
+			}
+
+			days := workingDaysBetween(project, time.Unix(blocker.CreatedAt, 0).In(loc), now)
+			if days < project.BlockerEscalationDays {
+				continue
+			}
+
+			err = bot.escalateBlocker(project, blocker, days)
+			if err != nil {
+				log.Error("escalateBlocker failed: ", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bot *Bot) escalateBlocker(project model.Project, blocker model.Blocker, days int) error {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	blockerEscalation, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "blockerEscalation",
+			Other: "<@{{.User}}> is blocked in #{{.Channel}} for {{.Days}} working days: {{.Text}}. Use `/blocker_ack {{.ID}}` if you are on it",
+		},
+		TemplateData: map[string]interface{}{
+			"User":    blocker.UserID,
+			"Channel": project.ChannelName,
+			"Days":    days,
+			"Text":    blocker.Text,
+			"ID":      blocker.ID,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	notified := false
+	for _, standuper := range standupers {
+		if standuper.Role != "pm" {
+			continue
+		}
+		err = bot.SendUserMessage(standuper.UserID, blockerEscalation)
+		if err != nil {
+			log.Error("SendUserMessage failed: ", err)
+			continue
+		}
+		notified = true
+	}
+	if !notified {
+		return nil
+	}
+
+	blocker.EscalatedAt = time.Now().Unix()
+	_, err = bot.db.UpdateBlocker(blocker)
+	return err
+}
+
+func (bot *Bot) listBlockers(command slack.SlashCommand) string {
+	blockers, err := bot.db.ListProjectUnresolvedBlockers(command.ChannelID)
+	if err != nil || len(blockers) == 0 {
+		noBlockers, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "noBlockers",
+				Other: "No open blockers in the channel",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return noBlockers
+	}
+
+	list := []string{}
+	for _, blocker := range blockers {
+		since := time.Unix(blocker.CreatedAt, 0).Format("2006-01-02")
+		list = append(list, fmt.Sprintf("#%v <@%v> (%v, %v): %v", blocker.ID, blocker.UserID, blocker.Status, since, blocker.Text))
+	}
+
+	showBlockers, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "showBlockers",
+			Other: "Open blockers:\n{{.Blockers}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Blockers": strings.Join(list, "\n"),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return showBlockers
+}
+
+//changeBlockerStatus acknowledges or resolves blocker with ID given in command text
+func (bot *Bot) changeBlockerStatus(command slack.SlashCommand, status string) string {
+	id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(command.Text), "#"), 10, 64)
+	if err != nil {
+		wrongBlockerID, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "wrongBlockerID",
+				Other: "Specify blocker number, see /blockers for the list",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return wrongBlockerID
+	}
+
+	blocker, err := bot.db.GetBlocker(id)
+	if err != nil || blocker.WorkspaceID != bot.workspace.WorkspaceID {
+		wrongBlockerID, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "wrongBlockerID",
+				Other: "Specify blocker number, see /blockers for the list",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return wrongBlockerID
+	}
+
+	if status == model.BlockerAcknowledged {
+		err = blocker.Acknowledge(command.UserID, time.Now().Unix())
+	} else {
+		err = blocker.Resolve(command.UserID, time.Now().Unix())
+	}
+	if err == nil {
+		_, err = bot.db.UpdateBlocker(blocker)
+	}
+	if err != nil {
+		failedUpdateBlocker, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "failedUpdateBlocker",
+				Other: "Failed to update blocker: {{.Error}}",
+			},
+			TemplateData: map[string]interface{}{
+				"Error": err,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return failedUpdateBlocker
+	}
+
+	blockerUpdated, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "blockerUpdated",
+			Other: "Blocker #{{.ID}} is {{.Status}}",
+		},
+		TemplateData: map[string]interface{}{
+			"ID":     blocker.ID,
+			"Status": blocker.Status,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return blockerUpdated
+}
@@ -0,0 +1,51 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitBlockers(t *testing.T) {
+	testCases := []struct {
+		text     string
+		blockers []string
+	}{
+		{"", []string{}},
+		{"nothing", []string{}},
+		{"Нет.", []string{}},
+		{"waiting for staging access", []string{"waiting for staging access"}},
+		{"- waiting for staging access;\n- no designs for the profile page\n", []string{"waiting for staging access", "no designs for the profile page"}},
+	}
+	for _, tt := range testCases {
+		assert.Equal(t, tt.blockers, splitBlockers(tt.text))
+	}
+}
+
+func TestSameBlocker(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		same bool
+	}{
+		{"waiting for staging access", "still waiting for staging access", true},
+		{"Waiting for staging access", "staging access is not granted yet, waiting", true},
+		{"no designs for the profile page", "waiting for staging access", false},
+		{"ci is red", "db is down", false},
+	}
+	for _, tt := range testCases {
+		assert.Equal(t, tt.same, sameBlocker(tt.a, tt.b), tt.a+" / "+tt.b)
+	}
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	project := model.Project{SubmissionDays: "monday, tuesday, wednesday, thursday, friday"}
+	friday := time.Date(2019, 8, 2, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, workingDaysBetween(project, friday, friday.Add(time.Hour)))
+	assert.Equal(t, 0, workingDaysBetween(project, friday, friday.AddDate(0, 0, 2)))
+	assert.Equal(t, 1, workingDaysBetween(project, friday, friday.AddDate(0, 0, 3)))
+	assert.Equal(t, 3, workingDaysBetween(project, friday, friday.AddDate(0, 0, 5)))
+	assert.Equal(t, 0, workingDaysBetween(model.Project{}, friday, friday.AddDate(0, 0, 5)))
+}
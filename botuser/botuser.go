@@ -42,6 +42,13 @@ type Bot struct {
 	slack     *slack.Client
 	bundle    *i18n.Bundle
 	quitChan  chan struct{}
+
+	tzMutex    sync.Mutex
+	profileTZs map[string]profileTZ
+
+	captureMutex   sync.Mutex
+	captureModes   map[string]string
+	captureModesAt time.Time
 }
 
 //New creates new Bot instance
@@ -66,27 +73,15 @@ func (bot *Bot) Start() {
 
 	wg.Add(1)
 	go func() {
+		bot.runScheduledWork()
+
 		ticker := time.NewTicker(time.Second * 60).C
 		for {
 			select {
 			case <-ticker:
-				err := bot.notifyChannels()
-				if err != nil {
-					log.Error("notifyChannels failed: ", err)
-				}
-				err = bot.CallDisplayYesterdayTeamReport()
-				if err != nil {
-					log.Error("CallDisplayYesterdayTeamReport failed: ", err)
-				}
-				err = bot.CallDisplayWeeklyTeamReport()
-				if err != nil {
-					log.Error("CallDisplayWeeklyTeamReport failed: ", err)
-				}
-				err = bot.remindAboutWorklogs()
-				if err != nil {
-					log.Error("remindAboutWorklogs failed: ", err)
-				}
+				bot.runScheduledWork()
 			case <-bot.quitChan:
+				bot.leaveScheduler()
 				wg.Done()
 				return
 			}
@@ -94,6 +89,23 @@ func (bot *Bot) Start() {
 	}()
 }
 
+//runScheduledWork runs jobs, standup threads and blocker escalation if this replica leads the workspace scheduler
+func (bot *Bot) runScheduledWork() {
+	if !bot.leadsScheduler(time.Now()) {
+		return
+	}
+
+	bot.runScheduler()
+	err := bot.openStandupThreads()
+	if err != nil {
+		log.Error("openStandupThreads failed: ", err)
+	}
+	err = bot.escalateBlockers()
+	if err != nil {
+		log.Error("escalateBlockers failed: ", err)
+	}
+}
+
 func (bot *Bot) send(msg *Message) error {
 	if msg.Type == "message" {
 		err := bot.SendMessage(msg.Channel, msg.Text, msg.Attachments)
@@ -124,19 +136,37 @@ func (bot *Bot) Stop() {
 
 //HandleMessage handles slack message event
 func (bot *Bot) HandleMessage(msg *slack.MessageEvent) error {
-	if !strings.Contains(msg.Msg.Text, bot.workspace.BotUserID) {
+	if strings.HasPrefix(msg.Channel, "D") && msg.SubType == typeMessage && msg.User != bot.workspace.BotUserID {
+		return bot.handleConversationMessage(msg)
+	}
+
+	if msg.SubType == "bot_message" {
+		return nil
+	}
+
+	//messages mentioning the bot are standups in any channel, others only in projects
+	//capturing every message or thread replies
+	mentioned := strings.Contains(msg.Msg.Text, bot.workspace.BotUserID)
+	if !mentioned && !bot.capturesUnmentioned(msg.Channel) {
+		return nil
+	}
+
+	//untracked channels use default standup rules
+	project, _ := bot.db.SelectProject(msg.Channel)
+
+	if !bot.isStandupMessage(msg, project) {
 		return nil
 	}
 	msg.Team = bot.workspace.WorkspaceID
 	switch msg.SubType {
 	case typeMessage:
-		_, err := bot.handleNewMessage(msg)
+		_, err := bot.handleNewMessage(msg, project)
 		if err != nil {
 			log.Error("NEW MESSAGE FAILED: ", err)
 			return err
 		}
 	case typeEditMessage:
-		_, err := bot.handleEditMessage(msg)
+		_, err := bot.handleEditMessage(msg, project)
 		if err != nil {
 			return err
 		}
@@ -145,15 +175,12 @@ func (bot *Bot) HandleMessage(msg *slack.MessageEvent) error {
 		if err != nil {
 			return err
 		}
-	case "bot_message":
-		return nil
 	}
 	return nil
 }
 
-func (bot *Bot) handleNewMessage(msg *slack.MessageEvent) (string, error) {
-
-	problem := bot.analizeStandup(msg.Msg.Text)
+func (bot *Bot) handleNewMessage(msg *slack.MessageEvent, project model.Project) (string, error) {
+	problem := bot.analizeStandup(msg.Msg.Text, project)
 	if problem != "" {
 		err := bot.send(&Message{
 			Type:    "ephemeral",
@@ -164,17 +191,28 @@ func (bot *Bot) handleNewMessage(msg *slack.MessageEvent) (string, error) {
 		return problem, err
 	}
 
-	_, err := bot.db.CreateStandup(model.Standup{
+	standup := model.Standup{
 		CreatedAt:   time.Now().Unix(),
 		WorkspaceID: msg.Team,
 		ChannelID:   msg.Channel,
 		UserID:      msg.User,
 		Comment:     msg.Msg.Text,
 		MessageTS:   msg.Msg.Timestamp,
-	})
+	}
+	bot.fillSections(&standup, project)
+	project = bot.standuperProject(project, standup.UserID)
+	project = bot.fillCheckIn(&standup, project)
+	fillLateness(&standup, project)
+	fillStandupDate(&standup, project)
+
+	standup, err := bot.db.CreateStandup(standup)
 	if err != nil {
 		return "", err
 	}
+	err = bot.trackBlockers(standup)
+	if err != nil {
+		log.Error("trackBlockers failed: ", err)
+	}
 	item := slack.ItemRef{
 		Channel:   msg.Channel,
 		Timestamp: msg.Msg.Timestamp,
@@ -188,8 +226,8 @@ func (bot *Bot) handleNewMessage(msg *slack.MessageEvent) (string, error) {
 	return "standup saved", nil
 }
 
-func (bot *Bot) handleEditMessage(msg *slack.MessageEvent) (string, error) {
-	problem := bot.analizeStandup(msg.SubMessage.Text)
+func (bot *Bot) handleEditMessage(msg *slack.MessageEvent, project model.Project) (string, error) {
+	problem := bot.analizeStandup(msg.SubMessage.Text, project)
 	if problem != "" {
 		err := bot.send(&Message{
 			Type:    "ephemeral",
@@ -202,25 +240,47 @@ func (bot *Bot) handleEditMessage(msg *slack.MessageEvent) (string, error) {
 
 	standup, err := bot.db.SelectStandupByMessageTS(msg.SubMessage.Timestamp)
 	if err == nil {
+		revision := standup.Revision(model.RevisionEdited)
 		standup.Comment = msg.SubMessage.Text
-		_, err := bot.db.UpdateStandup(standup)
+		bot.fillSections(&standup, project)
+		fillStandupDate(&standup, bot.standuperProject(project, standup.UserID))
+		standup, err := bot.db.UpdateStandup(standup)
+		if err != nil {
+			return "", err
+		}
+		_, err = bot.db.CreateStandupRevision(revision)
 		if err != nil {
 			return "", err
 		}
+		err = bot.trackBlockers(standup)
+		if err != nil {
+			log.Error("trackBlockers failed: ", err)
+		}
 		return "standup updated", nil
 	}
 
-	standup, err = bot.db.CreateStandup(model.Standup{
+	standup = model.Standup{
 		CreatedAt:   time.Now().Unix(),
 		WorkspaceID: msg.Team,
 		ChannelID:   msg.Channel,
 		UserID:      msg.SubMessage.User,
 		Comment:     msg.SubMessage.Text,
 		MessageTS:   msg.SubMessage.Timestamp,
-	})
+	}
+	bot.fillSections(&standup, project)
+	project = bot.standuperProject(project, standup.UserID)
+	project = bot.fillCheckIn(&standup, project)
+	fillLateness(&standup, project)
+	fillStandupDate(&standup, project)
+
+	standup, err = bot.db.CreateStandup(standup)
 	if err != nil {
 		return "", err
 	}
+	err = bot.trackBlockers(standup)
+	if err != nil {
+		log.Error("trackBlockers failed: ", err)
+	}
 
 	item := slack.ItemRef{
 		Channel:   msg.Channel,
@@ -242,113 +302,131 @@ func (bot *Bot) handleDeleteMessage(msg *slack.MessageEvent) (string, error) {
 		return "", nil
 	}
 
-	err = bot.db.DeleteStandup(standup.ID)
+	_, err = bot.db.CreateStandupRevision(standup.Revision(model.RevisionRetracted))
 	if err != nil {
 		return "", err
 	}
 
-	return "standup deleted", nil
-}
-
-func (bot *Bot) submittedStandupToday(userID, channelID string) bool {
-	standup, err := bot.db.SelectLatestStandupByUser(userID, channelID)
+	err = bot.db.RetractStandup(standup.ID, time.Now().Unix())
 	if err != nil {
-		return false
+		return "", err
 	}
 
-	userProfile, err := bot.slack.GetUserInfo(userID)
+	return "standup retracted", nil
+}
+
+//submittedStandupToday tells if the user submitted standup for the check-in today
+func (bot *Bot) submittedStandupToday(userID, channelID, checkIn string) bool {
+	project, err := bot.db.SelectProject(channelID)
 	if err != nil {
 		log.Error(err)
 		return false
 	}
+	project = bot.standuperProject(project, userID)
 
-	loc := time.FixedZone(userProfile.TZ, userProfile.TZOffset)
-
-	if time.Unix(standup.CreatedAt, 0).Day() == time.Now().UTC().In(loc).Day() {
+	if bot.answeredCheckIns(userID, channelID, standupDate(project, time.Now()))[checkIn] {
 		log.Info("not non reporter: ", userID)
 		return true
 	}
 	return false
 }
 
-func (bot *Bot) analizeStandup(message string) string {
+func (bot *Bot) analizeStandup(message string, project model.Project) string {
 	errors := []string{}
 	message = strings.ToLower(message)
 
-	var mentionsYesterdayWork, mentionsTodayPlans, mentionsProblem bool
-
-	for _, work := range yesterdayWorkKeys {
-		if strings.Contains(message, work) {
-			mentionsYesterdayWork = true
+	warnings := map[string]*i18n.Message{
+		"done": {
+			ID:    "noYesterdayMention",
+			Other: "- no 'yesterday' keywords detected: {{.Keywords}}",
+		},
+		"planned": {
+			ID:    "noTodayMention",
+			Other: "- no 'today' keywords detected: {{.Keywords}}",
+		},
+		"blockers": {
+			ID:    "noProblemsMention",
+			Other: "- no 'problems' keywords detected: {{.Keywords}}",
+		},
+	}
+
+	for _, rule := range standupRules(project) {
+		if !rule.required {
+			continue
 		}
-	}
 
-	for _, plan := range todayPlansKeys {
-		if strings.Contains(message, plan) {
-			mentionsTodayPlans = true
+		mentioned := false
+		for _, k := range rule.keywords {
+			if i, _ := k.index(message); i >= 0 {
+				mentioned = true
+			}
 		}
-	}
-
-	for _, problem := range problemKeys {
-		if strings.Contains(message, problem) {
-			mentionsProblem = true
+		if mentioned {
+			continue
 		}
-	}
 
-	if !mentionsYesterdayWork {
-		warnings, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-			DefaultMessage: &i18n.Message{
-				ID:    "noYesterdayMention",
-				Other: "- no 'yesterday' keywords detected: {{.Keywords}}",
-			},
-			TemplateData: map[string]interface{}{
-				"Keywords": strings.Join(yesterdayWorkKeys, ", "),
-			},
-		})
-		if err != nil {
-			log.Error(err)
-		}
-		errors = append(errors, warnings)
-	}
-	if !mentionsTodayPlans {
-		warnings, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-			DefaultMessage: &i18n.Message{
-				ID:    "noTodayMention",
-				Other: "- no 'today' keywords detected: {{.Keywords}}",
-			},
-			TemplateData: map[string]interface{}{
-				"Keywords": strings.Join(todayPlansKeys, ", "),
-			},
-		})
-		if err != nil {
-			log.Error(err)
-		}
-		errors = append(errors, warnings)
-	}
-	if !mentionsProblem {
-		warnings, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-			DefaultMessage: &i18n.Message{
-				ID:    "noProblemsMention",
-				Other: "- no 'problems' keywords detected: {{.Keywords}}",
-			},
+		warning, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: warnings[rule.name],
 			TemplateData: map[string]interface{}{
-				"Keywords": strings.Join(problemKeys, ", "),
+				"Keywords": rule.keys(),
 			},
 		})
 		if err != nil {
 			log.Error(err)
 		}
-		errors = append(errors, warnings)
+		errors = append(errors, warning)
 	}
 	return strings.Join(errors, ", ")
 }
 
 // SendMessage posts a message in a specified channel visible for everyone
 func (bot *Bot) SendMessage(channel, message string, attachments []slack.Attachment) error {
-	_, _, err := bot.slack.PostMessage(channel, message, slack.PostMessageParameters{Attachments: attachments})
+	_, err := bot.postMessage(channel, message, "", attachments)
 	return err
 }
 
+//postMessage posts a message (in a thread if threadTS is set) and returns its timestamp
+func (bot *Bot) postMessage(channel, message, threadTS string, attachments []slack.Attachment) (string, error) {
+	_, ts, err := bot.slack.PostMessage(channel, message, slack.PostMessageParameters{Attachments: attachments, ThreadTimestamp: threadTS})
+	return ts, err
+}
+
+//postStandupSummary posts standup sections to the standup channel on behalf of
+//the user and fills standup comment and message timestamp
+func (bot *Bot) postStandupSummary(standup *model.Standup) error {
+	summary, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "standupSummary",
+			Other: "<@{{.User}}> standup:\n*Done:* {{.Done}}\n*Planned:* {{.Planned}}\n*Blockers:* {{.Blockers}}",
+		},
+		TemplateData: map[string]interface{}{
+			"User":     standup.UserID,
+			"Done":     standup.Done,
+			"Planned":  standup.Planned,
+			"Blockers": standup.Blockers,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	//projects capturing standups in threads keep them in the daily thread
+	threadTS := ""
+	project, err := bot.db.SelectProject(standup.ChannelID)
+	if err == nil && project.CaptureMode == model.CaptureThread {
+		threadTS = project.ThreadTS
+	}
+
+	ts, err := bot.postMessage(standup.ChannelID, summary, threadTS, nil)
+	if err != nil {
+		return err
+	}
+
+	standup.Comment = summary
+	standup.MessageTS = ts
+	return nil
+}
+
 // SendEphemeralMessage posts a message in a specified channel which is visible only for selected user
 func (bot *Bot) SendEphemeralMessage(channel, user, message string) error {
 	_, err := bot.slack.PostEphemeral(channel, user, slack.MsgOptionText(message, true))
@@ -411,10 +489,40 @@ func (bot *Bot) ImplementCommands(command slack.SlashCommand) string {
 		return bot.modifyDeadline(command)
 	case "/tz":
 		return bot.modifyTZ(command)
+	case "/deadline_mode":
+		return bot.modifyDeadlineMode(command)
 	case "/submittion_days":
 		return bot.modifySubmittionDays(command)
 	case "/onbording_message":
 		return bot.modifyOnbordingMessage(command)
+	case "/standup_rules":
+		return bot.modifyStandupRules(command)
+	case "/dm_standup":
+		return bot.modifyDMStandupTime(command)
+	case "/checkin":
+		return bot.modifyCheckIns(command)
+	case "/standup":
+		return bot.openStandupModal(command)
+	case "/capture_mode":
+		return bot.modifyCaptureMode(command)
+	case "/blockers":
+		return bot.listBlockers(command)
+	case "/blocker_ack":
+		return bot.changeBlockerStatus(command, model.BlockerAcknowledged)
+	case "/blocker_resolve":
+		return bot.changeBlockerStatus(command, model.BlockerResolved)
+	case "/standup_for":
+		return bot.standupFor(command)
+	case "/notifications":
+		return bot.modifyNotifications(command)
+	case "/ooo":
+		return bot.outOfOffice(command)
+	case "/skip":
+		return bot.skipStandup(command)
+	case "/snooze":
+		return bot.snoozeReminders(command)
+	case "/report":
+		return bot.reportCommand(command)
 	default:
 		return ""
 	}
@@ -438,14 +546,6 @@ func (bot *Bot) SetProperties(settings *model.Workspace) *model.Workspace {
 }
 
 func (bot *Bot) remindAboutWorklogs() error {
-	if time.Now().AddDate(0, 0, 1).Day() != 1 {
-		return nil
-	}
-
-	if time.Now().Hour() != 10 || time.Now().Minute() != 0 {
-		return nil
-	}
-
 	users, err := bot.slack.GetUsers()
 	if err != nil {
 		return err
@@ -53,9 +53,21 @@ func setupBot() *Bot {
 
 func TestAnalizeStandup(t *testing.T) {
 
-	errors := bot.analizeStandup("yesterday, today, issues")
+	errors := bot.analizeStandup("yesterday, today, issues", model.Project{})
 	assert.Equal(t, "", errors)
 
-	errors = bot.analizeStandup("wrong standup")
+	errors = bot.analizeStandup("wrong standup", model.Project{})
 	assert.Equal(t, "- no 'yesterday' keywords detected: yesterday, friday, вчера, пятниц, - no 'today' keywords detected: today, сегодня, - no 'problems' keywords detected: issue, мешает", errors)
+
+	project := model.Project{
+		DoneKeys:         "gestern",
+		PlannedKeys:      "/heute|morgen/",
+		OptionalSections: "blockers",
+	}
+
+	errors = bot.analizeStandup("Gestern: tests, heute: deploy", project)
+	assert.Equal(t, "", errors)
+
+	errors = bot.analizeStandup("yesterday, today, issues", project)
+	assert.Equal(t, "- no 'yesterday' keywords detected: gestern, - no 'today' keywords detected: /heute|morgen/", errors)
 }
@@ -0,0 +1,230 @@
+package botuser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//isStandupMessage checks whether channel message should be treated as a standup
+//according to the project capture mode
+func (bot *Bot) isStandupMessage(msg *slack.MessageEvent, project model.Project) bool {
+	if project.CaptureMode != model.CaptureAll && project.CaptureMode != model.CaptureThread {
+		return strings.Contains(msg.Msg.Text, bot.workspace.BotUserID)
+	}
+
+	message := msg.Msg
+	switch msg.SubType {
+	case typeMessage:
+	case typeEditMessage:
+		if msg.SubMessage == nil {
+			return false
+		}
+		message = *msg.SubMessage
+	case typeDeleteMessage:
+		//deleted message is removed only if it was saved as a standup
+		return true
+	default:
+		return false
+	}
+
+	if message.User == "" || message.User == bot.workspace.BotUserID {
+		return false
+	}
+
+	if project.CaptureMode == model.CaptureAll && message.ThreadTimestamp != "" && message.ThreadTimestamp != message.Timestamp {
+		return false
+	}
+
+	if project.CaptureMode == model.CaptureThread && (project.ThreadTS == "" || message.ThreadTimestamp != project.ThreadTS) {
+		return false
+	}
+
+	_, err := bot.db.FindStansuperByUserID(message.User, msg.Channel)
+	return err == nil
+}
+
+//captureModesTTL is how long capture modes of workspace projects are cached
+const captureModesTTL = time.Minute
+
+//capturesUnmentioned tells if the channel is a project capturing standups that do not
+//mention the bot. Capture modes of workspace projects are cached so that plain channel
+//messages rarely reach the database
+func (bot *Bot) capturesUnmentioned(channelID string) bool {
+	bot.captureMutex.Lock()
+	modes, loadedAt := bot.captureModes, bot.captureModesAt
+	bot.captureMutex.Unlock()
+
+	if modes == nil || time.Since(loadedAt) > captureModesTTL {
+		projects, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+		if err != nil {
+			log.Error("capturesUnmentioned ListWorkspaceProjects failed: ", err)
+			return false
+		}
+		modes = map[string]string{}
+		for _, project := range projects {
+			modes[project.ChannelID] = project.CaptureMode
+		}
+		bot.captureMutex.Lock()
+		bot.captureModes, bot.captureModesAt = modes, time.Now()
+		bot.captureMutex.Unlock()
+	}
+
+	mode := modes[channelID]
+	return mode == model.CaptureAll || mode == model.CaptureThread
+}
+
+//forgetCaptureModes drops cached capture modes after the mode of a project changes
+func (bot *Bot) forgetCaptureModes() {
+	bot.captureMutex.Lock()
+	bot.captureModes = nil
+	bot.captureMutex.Unlock()
+}
+
+//openStandupThreads posts a daily message in projects capturing standups in threads.
+//Standupers reply to it with their standups
+func (bot *Bot) openStandupThreads() error {
+	projects, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if project.CaptureMode != model.CaptureThread {
+			continue
+		}
+
+		loc, err := time.LoadLocation(project.TZ)
+		if err != nil {
+			log.Error("openStandupThreads LoadLocation failed: ", err)
+			continue
+		}
+
+		now := time.Now().In(loc)
+		today := now.Format("2006-01-02")
+		if project.ThreadDate == today || !shouldSubmitStandupIn(&project, now) {
+			continue
+		}
+
+		standupThread, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "standupThread",
+				Other: "Standups for {{.Date}}. Reply in this thread with your standup",
+			},
+			TemplateData: map[string]interface{}{
+				"Date": today,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+
+		//the day is claimed before posting so that a failing save never reposts the thread,
+		//the claim is given back only when posting fails
+		previousDate := project.ThreadDate
+		project.ThreadDate = today
+		project, err = bot.db.UpdateProject(project)
+		if err != nil {
+			log.Error("openStandupThreads UpdateProject failed: ", err)
+			continue
+		}
+
+		ts, err := bot.postMessage(project.ChannelID, standupThread, "", nil)
+		if err != nil {
+			log.Error("openStandupThreads postMessage failed: ", err)
+			project.ThreadDate = previousDate
+		} else {
+			project.ThreadTS = ts
+		}
+		_, err = bot.db.UpdateProject(project)
+		if err != nil {
+			log.Error("openStandupThreads UpdateProject failed: ", err)
+		}
+	}
+
+	return nil
+}
+
+//modifyCaptureMode sets which channel messages are treated as standups.
+//Usage: "/capture_mode mention|all|thread". With no text it shows current mode
+func (bot *Bot) modifyCaptureMode(command slack.SlashCommand) string {
+	channel, err := bot.db.SelectProject(command.ChannelID)
+	if err != nil {
+		captureModeNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "captureModeNotSet",
+				Other: "Could not change standup capture mode",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return captureModeNotSet
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(command.Text))
+	if mode != "" {
+		channel.CaptureMode = mode
+		err = channel.Validate()
+		if err != nil {
+			wrongCaptureMode, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "wrongCaptureMode",
+					Other: "Unknown capture mode, use one of: mention, all, thread",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return wrongCaptureMode
+		}
+
+		channel, err = bot.db.UpdateProject(channel)
+		if err != nil {
+			log.Error(err)
+			captureModeNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "captureModeNotSet",
+					Other: "Could not change standup capture mode",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return captureModeNotSet
+		}
+		bot.forgetCaptureModes()
+	}
+
+	descriptions := map[string]*i18n.Message{
+		model.CaptureMention: {
+			ID:    "captureModeMention",
+			Other: "Standups are accepted from messages that mention Comedian",
+		},
+		model.CaptureAll: {
+			ID:    "captureModeAll",
+			Other: "Standups are accepted from all top-level messages of standupers",
+		},
+		model.CaptureThread: {
+			ID:    "captureModeThread",
+			Other: "Standups are accepted from standupers replies in the daily standup thread",
+		},
+	}
+
+	description, ok := descriptions[channel.CaptureMode]
+	if !ok {
+		description = descriptions[model.CaptureMention]
+	}
+
+	captureMode, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: description,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return captureMode
+}
@@ -0,0 +1,45 @@
+package botuser
+
+import (
+	"testing"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStandupMessageMentionMode(t *testing.T) {
+	b := &Bot{workspace: &model.Workspace{BotUserID: "UBOT"}}
+
+	msg := &slack.MessageEvent{}
+	msg.Msg.Text = "<@UBOT> yesterday, today, issues"
+	assert.True(t, b.isStandupMessage(msg, model.Project{}))
+	assert.True(t, b.isStandupMessage(msg, model.Project{CaptureMode: model.CaptureMention}))
+
+	msg.Msg.Text = "yesterday, today, issues"
+	assert.False(t, b.isStandupMessage(msg, model.Project{}))
+	assert.False(t, b.isStandupMessage(msg, model.Project{CaptureMode: model.CaptureMention}))
+}
+
+func TestIsStandupMessageThreadMode(t *testing.T) {
+	b := &Bot{workspace: &model.Workspace{BotUserID: "UBOT"}}
+	project := model.Project{CaptureMode: model.CaptureThread, ThreadTS: "100.1"}
+
+	msg := &slack.MessageEvent{}
+	msg.Msg.User = "UBOT"
+	msg.Msg.ThreadTimestamp = "100.1"
+	assert.False(t, b.isStandupMessage(msg, project))
+
+	msg.Msg.User = "USER"
+	msg.Msg.ThreadTimestamp = ""
+	assert.False(t, b.isStandupMessage(msg, project))
+
+	msg.Msg.ThreadTimestamp = "99.1"
+	assert.False(t, b.isStandupMessage(msg, project))
+
+	msg.SubType = "channel_join"
+	assert.False(t, b.isStandupMessage(msg, project))
+
+	msg.SubType = typeDeleteMessage
+	assert.True(t, b.isStandupMessage(msg, project))
+}
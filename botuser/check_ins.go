@@ -0,0 +1,297 @@
+package botuser
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//checkInProjects returns project as every its check-in sees it
+func checkInProjects(project model.Project) []model.Project {
+	projects := []model.Project{}
+	for _, checkIn := range project.StandupCheckIns() {
+		projects = append(projects, project.AtCheckIn(checkIn))
+	}
+	return projects
+}
+
+//checkInTime returns deadline of the check-in on the day of t in project timezone
+func checkInTime(project model.Project, t time.Time) time.Time {
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		loc = time.UTC
+	}
+	at, _ := atTimeOfDay(project.Deadline, t.In(loc))
+	return at
+}
+
+//sortedCheckIns returns project check-ins ordered by their deadlines
+func sortedCheckIns(project model.Project, t time.Time) []model.Project {
+	projects := checkInProjects(project)
+	sort.SliceStable(projects, func(i, j int) bool {
+		return checkInTime(projects[i], t).Before(checkInTime(projects[j], t))
+	})
+	return projects
+}
+
+//dueCheckIn returns check-in which deadline passed the latest before t, the earliest
+//check-in of the day if none has passed yet
+func dueCheckIn(project model.Project, t time.Time) model.Project {
+	projects := sortedCheckIns(project, t)
+	due := projects[0]
+	for _, p := range projects {
+		if !checkInTime(p, t).After(t) {
+			due = p
+		}
+	}
+	return due
+}
+
+//chooseCheckIn returns check-in standup submitted at t answers: the earliest check-in
+//of the day the user has not answered yet, or the due one if all are answered
+func chooseCheckIn(project model.Project, answered map[string]bool, t time.Time) model.Project {
+	for _, p := range sortedCheckIns(project, t) {
+		if !answered[p.CheckInName] {
+			return p
+		}
+	}
+	return dueCheckIn(project, t)
+}
+
+//answeredCheckIns returns names of check-ins the user submitted standups for on the date
+func (bot *Bot) answeredCheckIns(userID, channelID, date string) map[string]bool {
+	answered := map[string]bool{}
+	standups, err := bot.db.ListStandupsForDate(userID, channelID, date)
+	if err != nil {
+		log.Error("ListStandupsForDate failed: ", err)
+	}
+	for _, standup := range standups {
+		answered[standup.CheckIn] = true
+	}
+	return answered
+}
+
+//fillCheckIn tags standup with the check-in it answers and returns project as the check-in sees it
+func (bot *Bot) fillCheckIn(standup *model.Standup, project model.Project) model.Project {
+	t := time.Unix(standup.CreatedAt, 0)
+	answered := bot.answeredCheckIns(standup.UserID, standup.ChannelID, standupDate(project, t))
+	project = chooseCheckIn(project, answered, t)
+	standup.CheckIn = project.CheckInName
+	return project
+}
+
+//standupCheckIn returns project as the check-in the standup the user submits at t answers sees it
+func (bot *Bot) standupCheckIn(project model.Project, userID string, t time.Time) model.Project {
+	project = bot.standuperProject(project, userID)
+	answered := bot.answeredCheckIns(userID, project.ChannelID, standupDate(project, t))
+	return chooseCheckIn(project, answered, t)
+}
+
+//checkInQuestions returns questions of the check-in, their answers fill standup sections
+//in order. The unnamed check-in and check-ins without questions ask the usual ones
+func checkInQuestions(project model.Project) []string {
+	if project.CheckInName == "" {
+		return nil
+	}
+	for _, checkIn := range project.CheckIns {
+		if checkIn.Name != project.CheckInName {
+			continue
+		}
+		questions := checkIn.QuestionList()
+		if len(questions) > len(model.StandupSections) {
+			questions = questions[:len(model.StandupSections)]
+		}
+		return questions
+	}
+	return nil
+}
+
+//checkInText adds name and questions of the check-in to reminders about it,
+//reminders about the unnamed check-in stay as they are
+func (bot *Bot) checkInText(project model.Project, text string) string {
+	if project.CheckInName == "" || text == "" {
+		return text
+	}
+
+	message := &i18n.Message{
+		ID:    "checkInHeaderNoQuestions",
+		Other: "*{{.Name}}* check-in",
+	}
+	questions := ""
+	for _, checkIn := range project.CheckIns {
+		if checkIn.Name == project.CheckInName && checkIn.Questions != "" {
+			questions = checkIn.Questions
+			message = &i18n.Message{
+				ID:    "checkInHeader",
+				Other: "*{{.Name}}* check-in: {{.Questions}}",
+			}
+		}
+	}
+
+	header, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: message,
+		TemplateData: map[string]interface{}{
+			"Name":      project.CheckInName,
+			"Questions": questions,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return header + "\n" + text
+}
+
+//modifyCheckIns handles /checkin command: lists check-ins of the channel, adds or changes
+//a named check-in with `add name time questions` and removes one with `remove name`
+func (bot *Bot) modifyCheckIns(command slack.SlashCommand) string {
+	channel, err := bot.db.SelectProject(command.ChannelID)
+	if err != nil {
+		return bot.checkInsNotSet()
+	}
+
+	fields := strings.Fields(command.Text)
+	switch {
+	case len(fields) == 0:
+		return bot.listCheckIns(channel)
+	case fields[0] == "add" && len(fields) >= 3:
+		if _, ok := atTimeOfDay(fields[2], time.Now()); !ok {
+			break
+		}
+		checkIn := model.CheckIn{
+			CreatedAt:   time.Now().Unix(),
+			WorkspaceID: channel.WorkspaceID,
+			ChannelID:   channel.ChannelID,
+			Name:        strings.ToLower(fields[1]),
+			Deadline:    fields[2],
+			Questions:   strings.Join(fields[3:], " "),
+		}
+		for _, existing := range channel.CheckIns {
+			if existing.Name == checkIn.Name {
+				checkIn.ID = existing.ID
+			}
+		}
+		if checkIn.ID == 0 {
+			_, err = bot.db.CreateCheckIn(checkIn)
+		} else {
+			_, err = bot.db.UpdateCheckIn(checkIn)
+		}
+		if err != nil {
+			log.Error("could not save check-in: ", err)
+			return bot.checkInsNotSet()
+		}
+		checkInSaved, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "checkInSaved",
+				Other: "Check-in *{{.Name}}* is due at {{.Deadline}} in {{.TZ}} timezone",
+			},
+			TemplateData: map[string]interface{}{
+				"Name":     checkIn.Name,
+				"Deadline": checkIn.Deadline,
+				"TZ":       channel.TZ,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return checkInSaved
+	case fields[0] == "remove" && len(fields) == 2:
+		for _, checkIn := range channel.CheckIns {
+			if checkIn.Name != strings.ToLower(fields[1]) {
+				continue
+			}
+			err = bot.db.DeleteCheckIn(checkIn.ID)
+			if err != nil {
+				log.Error("DeleteCheckIn failed: ", err)
+				return bot.checkInsNotSet()
+			}
+			checkInRemoved, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "checkInRemoved",
+					Other: "Check-in *{{.Name}}* is removed",
+				},
+				TemplateData: map[string]interface{}{"Name": checkIn.Name},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return checkInRemoved
+		}
+	}
+
+	wrongCheckIn, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "wrongCheckIn",
+			Other: "Use `/checkin add evening 6pm What did you ship today?` to add a check-in, `/checkin remove evening` to remove it and `/checkin` to list check-ins",
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return wrongCheckIn
+}
+
+func (bot *Bot) checkInsNotSet() string {
+	checkInsNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "checkInsNotSet",
+			Other: "Could not change channel check-ins",
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return checkInsNotSet
+}
+
+//listCheckIns shows deadlines and questions of the channel check-ins
+func (bot *Bot) listCheckIns(channel model.Project) string {
+	if len(channel.CheckIns) == 0 {
+		noCheckIns, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "noCheckIns",
+				Other: "The channel has one standup a day, add more check-ins with `/checkin add evening 6pm What did you ship today?`",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return noCheckIns
+	}
+
+	lines := []string{}
+	for _, p := range sortedCheckIns(channel, time.Now()) {
+		message := &i18n.Message{
+			ID:    "showCheckIn",
+			Other: "*{{.Name}}* at {{.Deadline}}",
+		}
+		name := p.CheckInName
+		if name == "" {
+			message = &i18n.Message{
+				ID:    "showDefaultCheckIn",
+				Other: "standup at {{.Deadline}}",
+			}
+		}
+		line, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: message,
+			TemplateData: map[string]interface{}{
+				"Name":     name,
+				"Deadline": p.Deadline,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		for _, checkIn := range channel.CheckIns {
+			if checkIn.Name == name && checkIn.Questions != "" {
+				line += ": " + checkIn.Questions
+			}
+		}
+		lines = append(lines, "• "+line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,44 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChooseCheckIn(t *testing.T) {
+	project := model.Project{
+		ChannelID:      "chanID",
+		TZ:             "UTC",
+		Deadline:       "10am",
+		SubmissionDays: "weekdays",
+		CheckIns: []model.CheckIn{
+			{ChannelID: "chanID", Name: "evening", Deadline: "6pm"},
+			{ChannelID: "chanID", Name: "lunch", Deadline: "1pm"},
+		},
+	}
+
+	assert.Equal(t, 3, len(checkInProjects(project)))
+
+	morning := time.Date(2019, 9, 2, 9, 0, 0, 0, time.UTC)
+	afternoon := time.Date(2019, 9, 2, 15, 0, 0, 0, time.UTC)
+
+	//standups answer the earliest check-in not answered yet, even when it is late
+	assert.Equal(t, "", chooseCheckIn(project, map[string]bool{}, afternoon).CheckInName)
+	assert.Equal(t, "lunch", chooseCheckIn(project, map[string]bool{"": true}, morning).CheckInName)
+	assert.Equal(t, "evening", chooseCheckIn(project, map[string]bool{"": true, "lunch": true}, morning).CheckInName)
+
+	//when every check-in is answered the due one is updated
+	all := map[string]bool{"": true, "lunch": true, "evening": true}
+	assert.Equal(t, "lunch", chooseCheckIn(project, all, afternoon).CheckInName)
+
+	assert.Equal(t, "", dueCheckIn(project, morning).CheckInName)
+	assert.Equal(t, "lunch", dueCheckIn(project, afternoon).CheckInName)
+	assert.Equal(t, "1pm", dueCheckIn(project, afternoon).Deadline)
+
+	//project without check-ins has one unnamed check-in at its deadline
+	project.CheckIns = nil
+	assert.Equal(t, []model.Project{project}, checkInProjects(project))
+}
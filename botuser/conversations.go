@@ -0,0 +1,316 @@
+package botuser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	"github.com/olebedev/when"
+	"github.com/olebedev/when/rules/en"
+	"github.com/olebedev/when/rules/ru"
+	log "github.com/sirupsen/logrus"
+)
+
+func (bot *Bot) startProjectDMStandups(project model.Project) error {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	today := standupDate(project, time.Now())
+	excuses := bot.projectExcuses(project, today)
+	for _, standuper := range standupers {
+		if bot.onLeave(standuper.UserID, today) {
+			continue
+		}
+
+		if _, skipped := skippedBy(excuses, standuper.UserID); skipped {
+			continue
+		}
+
+		//the standup answers the next check-in the user has not answered yet
+		answered := bot.answeredCheckIns(standuper.UserID, standuper.ChannelID, today)
+		if answered[chooseCheckIn(project, answered, time.Now()).CheckInName] {
+			continue
+		}
+
+		//conversation left unfinished since the previous standup is no longer relevant
+		stale, err := bot.db.SelectConversation(standuper.UserID, standuper.ChannelID)
+		if err == nil {
+			err = bot.db.DeleteConversation(stale.ID)
+			if err != nil {
+				log.Error("DeleteConversation failed: ", err)
+				continue
+			}
+		}
+
+		conversation, err := bot.db.CreateConversation(model.StandupConversation{
+			CreatedAt:   time.Now().Unix(),
+			WorkspaceID: bot.workspace.WorkspaceID,
+			ChannelID:   standuper.ChannelID,
+			UserID:      standuper.UserID,
+		})
+		if err != nil {
+			log.Error("CreateConversation failed: ", err)
+			continue
+		}
+
+		//user answers one standup at a time, the rest wait until it is finished
+		active, err := bot.db.SelectActiveConversation(bot.workspace.WorkspaceID, standuper.UserID)
+		if err != nil || active.ID != conversation.ID {
+			continue
+		}
+
+		err = bot.askQuestion(conversation)
+		if err != nil {
+			log.Error("askQuestion failed: ", err)
+		}
+	}
+
+	return nil
+}
+
+//conversationQuestions returns questions of the check-in the conversation collects a standup for,
+//none if the check-in asks the usual ones
+func (bot *Bot) conversationQuestions(conversation model.StandupConversation) (model.Project, []string, error) {
+	project, err := bot.db.SelectProject(conversation.ChannelID)
+	if err != nil {
+		return project, nil, err
+	}
+	checkIn := bot.standupCheckIn(project, conversation.UserID, time.Now())
+	return project, checkInQuestions(checkIn), nil
+}
+
+func (bot *Bot) askQuestion(conversation model.StandupConversation) error {
+	project, ownQuestions, err := bot.conversationQuestions(conversation)
+	if err != nil {
+		return err
+	}
+
+	if conversation.Step < len(ownQuestions) {
+		question := ownQuestions[conversation.Step]
+		if conversation.Step == 0 {
+			question, err = bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "dmCheckInQuestion",
+					Other: "Standup in #{{.Channel}}. {{.Question}}",
+				},
+				TemplateData: map[string]interface{}{
+					"Channel":  project.ChannelName,
+					"Question": question,
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+		}
+		return bot.SendUserMessage(conversation.UserID, question)
+	}
+
+	questions := map[string]*i18n.Message{
+		"done": {
+			ID:    "dmQuestionDone",
+			Other: "Standup in #{{.Channel}}. What did you do yesterday?",
+		},
+		"planned": {
+			ID:    "dmQuestionPlanned",
+			Other: "What are you going to do today?",
+		},
+		"blockers": {
+			ID:    "dmQuestionBlockers",
+			Other: "Is anything blocking your progress?",
+		},
+	}
+
+	question, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: questions[model.StandupSections[conversation.Step]],
+		TemplateData:   map[string]interface{}{"Channel": project.ChannelName},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	return bot.SendUserMessage(conversation.UserID, question)
+}
+
+//handleConversationMessage saves user answer to the current question and asks the next one
+func (bot *Bot) handleConversationMessage(msg *slack.MessageEvent) error {
+	conversation, err := bot.db.SelectActiveConversation(bot.workspace.WorkspaceID, msg.User)
+	if err != nil {
+		return nil
+	}
+
+	answer := strings.TrimSpace(msg.Msg.Text)
+	switch model.StandupSections[conversation.Step] {
+	case "done":
+		conversation.Done = answer
+	case "planned":
+		conversation.Planned = answer
+	case "blockers":
+		conversation.Blockers = answer
+	}
+	conversation.Step++
+
+	//check-in with its own questions takes as many steps as it has questions
+	steps := len(model.StandupSections)
+	if _, ownQuestions, err := bot.conversationQuestions(conversation); err == nil && len(ownQuestions) > 0 {
+		steps = len(ownQuestions)
+	}
+
+	if conversation.Step < steps {
+		_, err = bot.db.UpdateConversation(conversation)
+		if err != nil {
+			return err
+		}
+		return bot.askQuestion(conversation)
+	}
+
+	return bot.finishConversation(conversation)
+}
+
+//finishConversation saves collected answers as a standup and posts it to the project channel
+func (bot *Bot) finishConversation(conversation model.StandupConversation) error {
+	standup := model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: conversation.WorkspaceID,
+		ChannelID:   conversation.ChannelID,
+		UserID:      conversation.UserID,
+		Done:        conversation.Done,
+		Planned:     conversation.Planned,
+		Blockers:    conversation.Blockers,
+	}
+
+	project, err := bot.db.SelectProject(standup.ChannelID)
+	if err != nil {
+		return err
+	}
+	project = bot.standuperProject(project, standup.UserID)
+	project = bot.fillCheckIn(&standup, project)
+	fillLateness(&standup, project)
+	fillStandupDate(&standup, project)
+
+	err = bot.postStandupSummary(&standup)
+	if err != nil {
+		return err
+	}
+
+	standup, err = bot.db.CreateStandup(standup)
+	if err != nil {
+		return err
+	}
+
+	err = bot.trackBlockers(standup)
+	if err != nil {
+		log.Error("trackBlockers failed: ", err)
+	}
+
+	err = bot.db.DeleteConversation(conversation.ID)
+	if err != nil {
+		return err
+	}
+
+	standupCollected, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "dmStandupCollected",
+			Other: "Thank you! Your standup is posted to the channel",
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	err = bot.SendUserMessage(conversation.UserID, standupCollected)
+	if err != nil {
+		log.Error("SendUserMessage failed: ", err)
+	}
+
+	next, err := bot.db.SelectActiveConversation(conversation.WorkspaceID, conversation.UserID)
+	if err != nil {
+		return nil
+	}
+	return bot.askQuestion(next)
+}
+
+func (bot *Bot) modifyDMStandupTime(command slack.SlashCommand) string {
+	channel, err := bot.db.SelectProject(command.ChannelID)
+	if err != nil {
+		dmStandupTimeNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "dmStandupTimeNotSet",
+				Other: "Could not change direct message standup time",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return dmStandupTimeNotSet
+	}
+
+	if strings.TrimSpace(command.Text) == "" {
+		channel.DMStandupTime = ""
+	} else {
+		w := when.New(nil)
+		w.Add(en.All...)
+		w.Add(ru.All...)
+
+		r, err := w.Parse(command.Text, time.Now())
+		if err != nil || r == nil {
+			wrongDMStandupTime, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "wrongDMStandupTime",
+					Other: "Could not recognize standup time. Use 9am or 09:00 formats",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return wrongDMStandupTime
+		}
+		channel.DMStandupTime = r.Text
+	}
+
+	_, err = bot.db.UpdateProject(channel)
+	if err != nil {
+		log.Error(err)
+		dmStandupTimeNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "dmStandupTimeNotSet",
+				Other: "Could not change direct message standup time",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return dmStandupTimeNotSet
+	}
+
+	if channel.DMStandupTime == "" {
+		removeDMStandupTime, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "removeDMStandupTime",
+				Other: "Direct message standups are turned off",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return removeDMStandupTime
+	}
+
+	updateDMStandupTime, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "updateDMStandupTime",
+			Other: "Standupers will be asked for standups in direct messages at {{.Time}} in {{.TZ}} timezone",
+		},
+		TemplateData: map[string]interface{}{
+			"Time": channel.DMStandupTime,
+			"TZ":   channel.TZ,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return updateDMStandupTime
+}
@@ -0,0 +1,282 @@
+package botuser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	log "github.com/sirupsen/logrus"
+)
+
+//ProjectEscalationPolicy returns escalation policy of the project. Projects without
+//policy remind non reporters at the deadline and then MaxReminders more times
+//every NotificationTime minutes
+func (bot *Bot) ProjectEscalationPolicy(project model.Project) model.EscalationPolicy {
+	if len(project.EscalationPolicy) > 0 {
+		return project.EscalationPolicy
+	}
+
+	policy := model.EscalationPolicy{{OffsetMinutes: 0, Audience: model.AudienceUser}}
+	for i := 1; i <= bot.workspace.MaxReminders; i++ {
+		policy = append(policy, model.EscalationStep{
+			OffsetMinutes: i * int(bot.conf.NotificationTime),
+			Audience:      model.AudienceUser,
+		})
+	}
+	return policy
+}
+
+//escalateNonReporters runs escalation steps of the project which time has come.
+//In user deadline mode steps run separately for standupers of every timezone
+func (bot *Bot) escalateNonReporters(project model.Project, now time.Time) error {
+	if project.DeadlineMode != model.DeadlineUser {
+		return bot.escalateLocalNonReporters(project, now, nil)
+	}
+
+	zones, err := bot.standupersByTZ(project)
+	if err != nil {
+		return err
+	}
+	for tz, users := range zones {
+		local := project
+		local.TZ = tz
+		err := bot.escalateLocalNonReporters(local, now, users)
+		if err != nil {
+			log.Errorf("escalation of %v in %v failed: %v", project.ChannelName, tz, err)
+		}
+	}
+	return nil
+}
+
+//escalateLocalNonReporters runs escalation steps for non reporters among users given,
+//nil users means all standupers of the project
+func (bot *Bot) escalateLocalNonReporters(project model.Project, now time.Time, users map[string]bool) error {
+	deadline, ok := standupDeadline(project, now)
+	if !ok {
+		return nil
+	}
+	now = now.In(deadline.Location()).Truncate(time.Minute)
+
+	var nonReporters []string
+	for i, step := range bot.ProjectEscalationPolicy(project) {
+		stepTime := deadline.Add(time.Duration(step.OffsetMinutes) * time.Minute)
+		if !stepTime.Equal(now) {
+			continue
+		}
+
+		if nonReporters == nil {
+			var err error
+			nonReporters, err = bot.findChannelNonReporters(project)
+			if err != nil {
+				return fmt.Errorf("could not get non reporters: %v", err)
+			}
+
+			//snoozed standupers are reminded when their snooze is over, standupers
+			//from other timezones are reminded at their own deadline
+			excuses := bot.projectExcuses(project, standupDate(project, now))
+			awake := []string{}
+			for _, user := range nonReporters {
+				if users != nil && !users[user] {
+					continue
+				}
+				if !snoozedBy(excuses, user, now) {
+					awake = append(awake, user)
+				}
+			}
+			nonReporters = awake
+		}
+		if len(nonReporters) == 0 {
+			return nil
+		}
+
+		err := bot.runEscalationStep(project, step, nonReporters)
+		if err != nil {
+			log.Errorf("escalation step %v of %v failed: %v", i+1, project.ChannelName, err)
+		}
+	}
+
+	return nil
+}
+
+func (bot *Bot) runEscalationStep(project model.Project, step model.EscalationStep, nonReporters []string) error {
+	inChannel, inDM := []string{}, []string{}
+	switch step.Audience {
+	case model.AudienceUser:
+		inChannel, inDM = bot.splitByDelivery(nonReporters)
+	case model.AudienceChannel:
+		inChannel = append(inChannel, nonReporters...)
+	case model.AudienceDM:
+		inDM = append(inDM, nonReporters...)
+	case model.AudiencePM:
+		return bot.escalateToPMs(project, step, nonReporters)
+	case model.AudienceReporting:
+		channelID, err := bot.escalationReportingChannel(project)
+		if err != nil || channelID == "" {
+			return err
+		}
+		return bot.SendMessage(channelID, bot.checkInText(project, bot.escalationMessage(project, step, nonReporters)), nil)
+	}
+
+	for _, user := range inDM {
+		message := step.Message
+		if message == "" {
+			message = bot.defaultDMReminder(project, step.OffsetMinutes)
+		} else {
+			message = renderEscalationMessage(message, project, step, []string{user})
+		}
+		err := bot.send(&Message{
+			Type: "direct",
+			User: user,
+			Text: bot.checkInText(project, message),
+		})
+		if err != nil {
+			log.Error("SendUserMessage failed: ", err)
+		}
+	}
+
+	if len(inChannel) == 0 {
+		return nil
+	}
+
+	var message string
+	var err error
+	switch {
+	case step.Message != "":
+		message = renderEscalationMessage(step.Message, project, step, inChannel)
+	case step.OffsetMinutes < 0:
+		message, err = bot.composeWarnMessage(inChannel, int64(-step.OffsetMinutes))
+	case step.OffsetMinutes == 0:
+		message, err = bot.composeAlarmMessage(inChannel)
+	default:
+		message, err = bot.composeRemindMessage(inChannel)
+	}
+	if err != nil {
+		return err
+	}
+
+	return bot.send(&Message{
+		Type:    "message",
+		Channel: project.ChannelID,
+		Text:    bot.checkInText(project, message),
+	})
+}
+
+//escalateToPMs privately tells project managers who has not submitted standup
+func (bot *Bot) escalateToPMs(project model.Project, step model.EscalationStep, nonReporters []string) error {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	message := bot.checkInText(project, bot.escalationMessage(project, step, nonReporters))
+	for _, standuper := range standupers {
+		if standuper.Role != "pm" {
+			continue
+		}
+		err = bot.SendUserMessage(standuper.UserID, message)
+		if err != nil {
+			log.Error("SendUserMessage failed: ", err)
+		}
+	}
+	return nil
+}
+
+//escalationReportingChannel returns ID of the channel reports on the project go to,
+//empty if neither the project nor the workspace has one
+func (bot *Bot) escalationReportingChannel(project model.Project) (string, error) {
+	channels, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+	return bot.reportingChannelID(project, channels), nil
+}
+
+//escalationMessage tells who has not submitted standup in the project
+func (bot *Bot) escalationMessage(project model.Project, step model.EscalationStep, nonReporters []string) string {
+	if step.Message != "" {
+		return renderEscalationMessage(step.Message, project, step, nonReporters)
+	}
+
+	escalateNonReporters, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "escalateNonReporters",
+			Other: "Standups in #{{.Channel}} are still missing from {{.Users}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Channel": project.ChannelName,
+			"Users":   mentions(nonReporters),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return escalateNonReporters
+}
+
+func (bot *Bot) defaultDMReminder(project model.Project, offset int) string {
+	message := &i18n.Message{
+		ID:    "dmRemindNonReporter",
+		Other: "You still haven't written a standup in #{{.Channel}}",
+	}
+	switch {
+	case offset < 0:
+		message = &i18n.Message{
+			ID:    "dmWarnNonReporter",
+			Other: "Standup in #{{.Channel}} is due in {{.Minutes}} minutes, do not forget to submit it",
+		}
+	case offset == 0:
+		message = &i18n.Message{
+			ID:    "dmAlarmNonReporter",
+			Other: "You have missed the standup deadline in #{{.Channel}}, please submit your standup",
+		}
+	}
+
+	reminder, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: message,
+		TemplateData: map[string]interface{}{
+			"Channel": project.ChannelName,
+			"Minutes": -offset,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return reminder
+}
+
+//renderEscalationMessage fills custom step message. Templates can use
+//{{.Users}}, {{.Channel}} and {{.Minutes}} from the deadline
+func renderEscalationMessage(text string, project model.Project, step model.EscalationStep, users []string) string {
+	tmpl, err := template.New("step").Parse(text)
+	if err != nil {
+		return text
+	}
+
+	minutes := step.OffsetMinutes
+	if minutes < 0 {
+		minutes = -minutes
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Users":   mentions(users),
+		"Channel": project.ChannelName,
+		"Minutes": minutes,
+	})
+	if err != nil {
+		return text
+	}
+	return buf.String()
+}
+
+func mentions(users []string) string {
+	tagged := make([]string, len(users))
+	for i, user := range users {
+		tagged[i] = "<@" + user + ">"
+	}
+	return strings.Join(tagged, ", ")
+}
@@ -0,0 +1,63 @@
+package botuser
+
+import (
+	"testing"
+
+	"github.com/maddevsio/comedian/config"
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectEscalationPolicy(t *testing.T) {
+	b := &Bot{
+		conf:      &config.Config{NotificationTime: 30},
+		workspace: &model.Workspace{MaxReminders: 2},
+	}
+
+	policy := b.ProjectEscalationPolicy(model.Project{})
+	assert.Equal(t, model.EscalationPolicy{
+		{OffsetMinutes: 0, Audience: model.AudienceUser},
+		{OffsetMinutes: 30, Audience: model.AudienceUser},
+		{OffsetMinutes: 60, Audience: model.AudienceUser},
+	}, policy)
+
+	custom := model.EscalationPolicy{
+		{OffsetMinutes: 0, Audience: model.AudienceChannel},
+		{OffsetMinutes: 60, Audience: model.AudiencePM},
+	}
+	assert.Equal(t, custom, b.ProjectEscalationPolicy(model.Project{EscalationPolicy: custom}))
+}
+
+func TestRenderEscalationMessage(t *testing.T) {
+	project := model.Project{ChannelName: "backend"}
+
+	testCases := []struct {
+		text    string
+		offset  int
+		users   []string
+		message string
+	}{
+		{"{{.Users}} missed standup in #{{.Channel}}", 30, []string{"U1", "U2"}, "<@U1>, <@U2> missed standup in #backend"},
+		{"{{.Minutes}} minutes left", -15, []string{"U1"}, "15 minutes left"},
+		{"{{.Unknown", 0, []string{"U1"}, "{{.Unknown"},
+	}
+	for _, tt := range testCases {
+		step := model.EscalationStep{OffsetMinutes: tt.offset, Audience: model.AudiencePM, Message: tt.text}
+		assert.Equal(t, tt.message, renderEscalationMessage(tt.text, project, step, tt.users))
+	}
+}
+
+func TestEscalationReportingChannel(t *testing.T) {
+	workspaceChannel := bot.workspace.ReportingChannel
+	bot.workspace.ReportingChannel = "ChannelWithNoDeadline"
+	defer func() { bot.workspace.ReportingChannel = workspaceChannel }()
+
+	//project reporting channel takes precedence over the one of the workspace
+	channelID, err := bot.escalationReportingChannel(model.Project{ChannelID: "CHANESC", ReportingChannel: "ChannelWithDeadline"})
+	assert.NoError(t, err)
+	assert.Equal(t, "CHAN321", channelID)
+
+	channelID, err = bot.escalationReportingChannel(model.Project{ChannelID: "CHANESC"})
+	assert.NoError(t, err)
+	assert.Equal(t, "CHAN123", channelID)
+}
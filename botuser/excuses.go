@@ -0,0 +1,282 @@
+package botuser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//maxSnooze is the longest delay of reminders, longer breaks are skips
+const maxSnooze = 12 * time.Hour
+
+//projectExcuses returns skips and snoozes of project standupers for the standup date
+func (bot *Bot) projectExcuses(project model.Project, date string) []model.Excuse {
+	excuses, err := bot.db.ListProjectExcuses(project.ChannelID, date)
+	if err != nil {
+		log.Error("ListProjectExcuses failed: ", err)
+	}
+	return excuses
+}
+
+//skippedBy returns the latest skip of the user among excuses
+func skippedBy(excuses []model.Excuse, userID string) (model.Excuse, bool) {
+	for _, excuse := range excuses {
+		if excuse.UserID == userID && excuse.Kind == model.ExcuseSkip {
+			return excuse, true
+		}
+	}
+	return model.Excuse{}, false
+}
+
+//snoozedBy tells if reminders of the user are delayed at the moment
+func snoozedBy(excuses []model.Excuse, userID string, now time.Time) bool {
+	for _, excuse := range excuses {
+		if excuse.UserID == userID && excuse.Snoozed(now) {
+			return true
+		}
+	}
+	return false
+}
+
+//parseSnooze recognizes snooze duration like 30m, 1h or 1h30m, plain numbers are minutes
+func parseSnooze(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		minutes, err := strconv.Atoi(strings.TrimSuffix(text, "min"))
+		if err != nil {
+			return 0, err
+		}
+		d = time.Duration(minutes) * time.Minute
+	}
+	if d < time.Minute || d > maxSnooze {
+		return 0, errors.New("snooze duration is out of range")
+	}
+	return d, nil
+}
+
+//formatSnooze shows duration as 30m, 2h or 1h30m
+func formatSnooze(d time.Duration) string {
+	text := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+//excuseProjects returns projects skip and snooze commands apply to: the project of
+//the channel if the user is its standuper, otherwise all projects of the user
+func (bot *Bot) excuseProjects(command slack.SlashCommand) []model.Project {
+	standupers, err := bot.db.FindStansupersByUserID(command.UserID)
+	if err != nil {
+		log.Error("FindStansupersByUserID failed: ", err)
+		return nil
+	}
+
+	projects := []model.Project{}
+	for _, standuper := range standupers {
+		if standuper.WorkspaceID != bot.workspace.WorkspaceID {
+			continue
+		}
+		if standuper.ChannelID == command.ChannelID {
+			project, err := bot.db.SelectProject(standuper.ChannelID)
+			if err != nil {
+				return nil
+			}
+			return []model.Project{project}
+		}
+		project, err := bot.db.SelectProject(standuper.ChannelID)
+		if err != nil {
+			log.Error("SelectProject failed: ", err)
+			continue
+		}
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+//skipStandup handles /skip command, the user is excused from today's standup
+func (bot *Bot) skipStandup(command slack.SlashCommand) string {
+	return bot.excuse(command, model.Excuse{
+		Kind:   model.ExcuseSkip,
+		Reason: strings.TrimSpace(command.Text),
+	}, &i18n.Message{
+		ID:    "standupSkipped",
+		Other: "You skip today's standup in {{.Channels}}, nobody will remind you about it",
+	})
+}
+
+//snoozeReminders handles /snooze command, today's reminders are delayed for the duration given
+func (bot *Bot) snoozeReminders(command slack.SlashCommand) string {
+	d, err := parseSnooze(command.Text)
+	if err != nil {
+		wrongSnooze, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "wrongSnooze",
+				Other: "Use `/snooze 30m` or `/snooze 2h` to delay today's reminders, up to 12 hours",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return wrongSnooze
+	}
+
+	return bot.excuse(command, model.Excuse{
+		Kind:  model.ExcuseSnooze,
+		Until: time.Now().Add(d).Unix(),
+	}, &i18n.Message{
+		ID:    "remindersSnoozed",
+		Other: "Reminders in {{.Channels}} are snoozed for {{.Duration}}",
+	})
+}
+
+//excuse records excuse for today's standup in every project the command applies to
+func (bot *Bot) excuse(command slack.SlashCommand, excuse model.Excuse, done *i18n.Message) string {
+	projects := bot.excuseProjects(command)
+	if len(projects) == 0 {
+		notStanduper, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "notStanduper",
+				Other: "You do not standup yet",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return notStanduper
+	}
+
+	now := time.Now()
+	channels := []string{}
+	for _, project := range projects {
+		excuse.CreatedAt = now.Unix()
+		excuse.WorkspaceID = bot.workspace.WorkspaceID
+		excuse.ChannelID = project.ChannelID
+		excuse.UserID = command.UserID
+		excuse.Date = standupDate(bot.standuperProject(project, command.UserID), now)
+
+		err := bot.saveExcuse(excuse, now)
+		if err != nil {
+			failedExcuse, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "failedExcuse",
+					Other: "Could not save it, please try again later",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return failedExcuse
+		}
+		channels = append(channels, "#"+project.ChannelName)
+	}
+
+	text, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: done,
+		TemplateData: map[string]interface{}{
+			"Channels": strings.Join(channels, ", "),
+			"Duration": formatSnooze(time.Duration(excuse.Until-excuse.CreatedAt) * time.Second),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return text
+}
+
+//saveExcuse stores the excuse, snoozes also get a job reminding when they are over.
+//Snooze is dropped if its job cannot be planned, so that retrying does not repeat it
+func (bot *Bot) saveExcuse(excuse model.Excuse, now time.Time) error {
+	excuse, err := bot.db.CreateExcuse(excuse)
+	if err != nil {
+		log.Error("CreateExcuse failed: ", err)
+		return err
+	}
+	if excuse.Kind != model.ExcuseSnooze {
+		return nil
+	}
+
+	_, err = bot.db.CreateJob(model.Job{
+		CreatedAt:   now.Unix(),
+		WorkspaceID: excuse.WorkspaceID,
+		ChannelID:   excuse.ChannelID,
+		Kind:        model.JobSnoozeOver,
+		RunAt:       time.Unix(excuse.Until, 0).Truncate(time.Minute).Unix(),
+		Status:      model.JobPending,
+	})
+	if err != nil {
+		log.Error("CreateJob failed: ", err)
+		if deleteErr := bot.db.DeleteExcuse(excuse.ID); deleteErr != nil {
+			log.Error("DeleteExcuse failed: ", deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+//remindSnoozed reminds standupers whose snooze is over right now and who still
+//have not submitted standup
+func (bot *Bot) remindSnoozed(project model.Project, now time.Time) {
+	now = now.Truncate(time.Minute)
+
+	snoozes, err := bot.db.ListEndingSnoozes(project.ChannelID, now.Unix(), now.Add(time.Minute).Unix())
+	if err != nil {
+		log.Error("ListEndingSnoozes failed: ", err)
+		return
+	}
+
+	for _, snooze := range snoozes {
+		local := dueCheckIn(bot.standuperProject(project, snooze.UserID), now)
+		deadline, ok := standupDeadline(local, now)
+		if !ok {
+			continue
+		}
+
+		excuses := bot.projectExcuses(project, snooze.Date)
+		if _, skipped := skippedBy(excuses, snooze.UserID); skipped || snoozedBy(excuses, snooze.UserID, now.Add(time.Minute)) {
+			continue
+		}
+		if bot.submittedStandupToday(snooze.UserID, project.ChannelID, local.CheckInName) {
+			continue
+		}
+
+		err := bot.send(&Message{
+			Type: "direct",
+			User: snooze.UserID,
+			Text: bot.checkInText(local, bot.defaultDMReminder(project, int(now.Sub(deadline).Minutes()))),
+		})
+		if err != nil {
+			log.Error("SendUserMessage failed: ", err)
+		}
+	}
+}
+
+//skippedText is shown in the daily report instead of standup of the user who skipped it
+func (bot *Bot) skippedText(excuse model.Excuse) string {
+	message := &i18n.Message{
+		ID:    "skippedStandup",
+		Other: "skipped: {{.Reason}} :see_no_evil: ",
+	}
+	if excuse.Reason == "" {
+		message = &i18n.Message{
+			ID:    "skippedStandupNoReason",
+			Other: "skipped :see_no_evil: ",
+		}
+	}
+
+	skipped, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: message,
+		TemplateData:   map[string]interface{}{"Reason": excuse.Reason},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return skipped
+}
@@ -0,0 +1,63 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSnooze(t *testing.T) {
+	testCases := []struct {
+		text     string
+		duration time.Duration
+		err      bool
+	}{
+		{"30m", 30 * time.Minute, false},
+		{" 1h30m ", 90 * time.Minute, false},
+		{"45", 45 * time.Minute, false},
+		{"15min", 15 * time.Minute, false},
+		{"12h", 12 * time.Hour, false},
+		{"13h", 0, true},
+		{"0", 0, true},
+		{"-10m", 0, true},
+		{"", 0, true},
+		{"later", 0, true},
+	}
+	for _, tt := range testCases {
+		d, err := parseSnooze(tt.text)
+		if tt.err {
+			assert.Error(t, err, tt.text)
+			continue
+		}
+		assert.NoError(t, err, tt.text)
+		assert.Equal(t, tt.duration, d, tt.text)
+	}
+
+	assert.Equal(t, "30m", formatSnooze(30*time.Minute))
+	assert.Equal(t, "2h", formatSnooze(2*time.Hour))
+	assert.Equal(t, "1h30m", formatSnooze(90*time.Minute+10*time.Second))
+}
+
+func TestExcuses(t *testing.T) {
+	now := time.Unix(1567400000, 0)
+	excuses := []model.Excuse{
+		{UserID: "foo", Kind: model.ExcuseSnooze, Until: now.Add(30 * time.Minute).Unix()},
+		{UserID: "bar", Kind: model.ExcuseSkip, Reason: "workshop"},
+		{UserID: "bar", Kind: model.ExcuseSkip, Reason: "sick"},
+		{UserID: "baz", Kind: model.ExcuseSnooze, Until: now.Add(-time.Minute).Unix()},
+	}
+
+	excuse, skipped := skippedBy(excuses, "bar")
+	assert.Equal(t, true, skipped)
+	assert.Equal(t, "workshop", excuse.Reason)
+
+	_, skipped = skippedBy(excuses, "foo")
+	assert.Equal(t, false, skipped)
+
+	assert.Equal(t, true, snoozedBy(excuses, "foo", now))
+	assert.Equal(t, false, snoozedBy(excuses, "foo", now.Add(30*time.Minute)))
+	assert.Equal(t, false, snoozedBy(excuses, "bar", now))
+	assert.Equal(t, false, snoozedBy(excuses, "baz", now))
+}
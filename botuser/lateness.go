@@ -0,0 +1,77 @@
+package botuser
+
+import (
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	log "github.com/sirupsen/logrus"
+)
+
+//standupDeadline returns project deadline for the day of t in project timezone.
+//There is no deadline if project has none set or the day is not a submission day
+func standupDeadline(project model.Project, t time.Time) (time.Time, bool) {
+	if project.Deadline == "" {
+		return time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		return time.Time{}, false
+	}
+	local := t.In(loc)
+
+	if !shouldSubmitStandupIn(&project, local) {
+		return time.Time{}, false
+	}
+
+	return atTimeOfDay(project.Deadline, local)
+}
+
+//fillLateness records deadline that applied when standup was submitted and how
+//many minutes after it the standup came, early standups get negative minutes
+func fillLateness(standup *model.Standup, project model.Project) {
+	submittedAt := time.Unix(standup.CreatedAt, 0)
+	deadline, ok := standupDeadline(project, submittedAt)
+	if !ok {
+		standup.DeadlineAt = 0
+		standup.LateMinutes = 0
+		return
+	}
+	standup.DeadlineAt = deadline.Unix()
+	standup.LateMinutes = int(submittedAt.Sub(deadline) / time.Minute)
+}
+
+//latenessText describes when standup was submitted relative to the deadline
+func (bot *Bot) latenessText(standup model.Standup) string {
+	if standup.DeadlineAt == 0 {
+		return ""
+	}
+
+	if !standup.Late() {
+		standupOnTime, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "standupOnTime",
+				Other: "on time",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return standupOnTime
+	}
+
+	standupLate, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "standupLate",
+			Other: "late by {{.Minutes}} min",
+		},
+		TemplateData: map[string]interface{}{
+			"Minutes": standup.LateMinutes,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return standupLate
+}
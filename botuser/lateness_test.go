@@ -0,0 +1,42 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillLateness(t *testing.T) {
+	project := model.Project{
+		Deadline:       "10:00",
+		TZ:             "Asia/Bishkek",
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+	}
+	loc, err := time.LoadLocation(project.TZ)
+	assert.NoError(t, err)
+
+	//2019-09-02 is monday, 2019-09-07 is saturday
+	testCases := []struct {
+		project     model.Project
+		submittedAt time.Time
+		deadlineAt  int64
+		lateMinutes int
+		late        bool
+	}{
+		{project, time.Date(2019, 9, 2, 9, 30, 0, 0, loc), time.Date(2019, 9, 2, 10, 0, 0, 0, loc).Unix(), -30, false},
+		{project, time.Date(2019, 9, 2, 10, 0, 40, 0, loc), time.Date(2019, 9, 2, 10, 0, 0, 0, loc).Unix(), 0, false},
+		{project, time.Date(2019, 9, 2, 11, 15, 0, 0, loc), time.Date(2019, 9, 2, 10, 0, 0, 0, loc).Unix(), 75, true},
+		{project, time.Date(2019, 9, 2, 6, 15, 0, 0, time.UTC), time.Date(2019, 9, 2, 10, 0, 0, 0, loc).Unix(), 135, true},
+		{project, time.Date(2019, 9, 7, 11, 0, 0, 0, loc), 0, 0, false},
+		{model.Project{TZ: "Asia/Bishkek", SubmissionDays: "monday"}, time.Date(2019, 9, 2, 11, 0, 0, 0, loc), 0, 0, false},
+	}
+	for _, tt := range testCases {
+		standup := model.Standup{CreatedAt: tt.submittedAt.Unix()}
+		fillLateness(&standup, tt.project)
+		assert.Equal(t, tt.deadlineAt, standup.DeadlineAt)
+		assert.Equal(t, tt.lateMinutes, standup.LateMinutes)
+		assert.Equal(t, tt.late, standup.Late())
+	}
+}
@@ -0,0 +1,46 @@
+package botuser
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//replicaID identifies this process among bot replicas sharing the database
+func (bot *Bot) replicaID() string {
+	if bot.conf.ReplicaID != "" {
+		return bot.conf.ReplicaID
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "comedian"
+	}
+	return fmt.Sprintf("%v-%v", hostname, os.Getpid())
+}
+
+//schedulerLease is the lease name of scheduled work of the workspace
+func (bot *Bot) schedulerLease() string {
+	return "scheduler/" + bot.workspace.WorkspaceID
+}
+
+//leadsScheduler takes or renews the scheduler lease of the workspace. Only the replica
+//holding it runs scheduled work, the others keep handling events and commands
+func (bot *Bot) leadsScheduler(now time.Time) bool {
+	ttl := time.Duration(bot.conf.LeaseSeconds) * time.Second
+	leads, err := bot.db.AcquireLease(bot.schedulerLease(), bot.replicaID(), now.Unix(), now.Add(ttl).Unix())
+	if err != nil {
+		log.Error("AcquireLease failed: ", err)
+		return false
+	}
+	return leads
+}
+
+//leaveScheduler releases the scheduler lease so another replica takes over without waiting for it to expire
+func (bot *Bot) leaveScheduler() {
+	err := bot.db.ReleaseLease(bot.schedulerLease(), bot.replicaID())
+	if err != nil {
+		log.Error("ReleaseLease failed: ", err)
+	}
+}
@@ -0,0 +1,267 @@
+package botuser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//standupCallbackID identifies standup modal in interaction payloads
+const standupCallbackID = "standup"
+
+var viewsOpenURL = "https://slack.com/api/views.open"
+
+//ViewSubmission represents view_submission payload Slack sends when user submits a modal
+type ViewSubmission struct {
+	Type  string `json:"type"`
+	Token string `json:"token"`
+	Team  struct {
+		ID string `json:"id"`
+	} `json:"team"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	View struct {
+		CallbackID      string `json:"callback_id"`
+		PrivateMetadata string `json:"private_metadata"`
+		State           struct {
+			Values map[string]map[string]struct {
+				Value string `json:"value"`
+			} `json:"values"`
+		} `json:"state"`
+	} `json:"view"`
+}
+
+//openStandupModal opens a modal with a text field for every standup section
+func (bot *Bot) openStandupModal(command slack.SlashCommand) string {
+	project, err := bot.db.SelectProject(command.ChannelID)
+	if err != nil {
+		standupModalFailed, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "standupModalFailed",
+				Other: "Could not open standup form",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return standupModalFailed
+	}
+
+	//the form asks questions of the check-in the standup answers
+	project = bot.standupCheckIn(project, command.UserID, time.Now())
+	err = bot.openView(command.TriggerID, bot.standupView(project))
+	if err != nil {
+		log.Error("openView failed: ", err)
+		standupModalFailed, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "standupModalFailed",
+				Other: "Could not open standup form",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return standupModalFailed
+	}
+
+	return ""
+}
+
+//standupView builds Block Kit modal for the project standup. Channel ID is kept
+//in private metadata to know where to post the standup once it is submitted
+func (bot *Bot) standupView(project model.Project) map[string]interface{} {
+	labels := map[string]*i18n.Message{
+		"done": {
+			ID:    "standupModalDone",
+			Other: "What did you do yesterday?",
+		},
+		"planned": {
+			ID:    "standupModalPlanned",
+			Other: "What are you going to do today?",
+		},
+		"blockers": {
+			ID:    "standupModalBlockers",
+			Other: "Is anything blocking your progress?",
+		},
+	}
+
+	blocks := []map[string]interface{}{}
+	for _, rule := range standupRules(project) {
+		label, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: labels[rule.name],
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		blocks = append(blocks, inputBlock(rule.name, label, !rule.required))
+	}
+
+	//check-in with its own questions asks them instead, answers fill sections in order
+	if questions := checkInQuestions(project); len(questions) > 0 {
+		blocks = []map[string]interface{}{}
+		for i, question := range questions {
+			blocks = append(blocks, inputBlock(model.StandupSections[i], question, i > 0))
+		}
+	}
+
+	title, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "standupModalTitle",
+			Other: "Standup in #{{.Channel}}",
+		},
+		TemplateData: map[string]interface{}{"Channel": project.ChannelName},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	//modal title is limited to 24 characters
+	if len([]rune(title)) > 24 {
+		title = string([]rune(title)[:24])
+	}
+
+	submit, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "standupModalSubmit",
+			Other: "Submit",
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	return map[string]interface{}{
+		"type":             "modal",
+		"callback_id":      standupCallbackID,
+		"private_metadata": project.ChannelID,
+		"title":            plainText(title),
+		"submit":           plainText(submit),
+		"blocks":           blocks,
+	}
+}
+
+//inputBlock is a multiline text field of the standup section
+func inputBlock(section, label string, optional bool) map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "input",
+		"block_id": section,
+		"optional": optional,
+		"label":    plainText(label),
+		"element": map[string]interface{}{
+			"type":      "plain_text_input",
+			"action_id": section,
+			"multiline": true,
+		},
+	}
+}
+
+func plainText(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "plain_text", "text": text}
+}
+
+//openView calls views.open Slack method
+func (bot *Bot) openView(triggerID string, view map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"trigger_id": triggerID,
+		"view":       view,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", viewsOpenURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json; charset=utf-8")
+	req.Header.Add("Authorization", "Bearer "+bot.workspace.BotAccessToken)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return err
+	}
+	if !response.OK {
+		return errors.New(response.Error)
+	}
+	return nil
+}
+
+//HandleViewSubmission saves standup submitted with the standup modal and posts it to the channel
+func (bot *Bot) HandleViewSubmission(submission ViewSubmission) error {
+	if submission.View.CallbackID != standupCallbackID {
+		return nil
+	}
+
+	answers := map[string]string{}
+	for _, section := range model.StandupSections {
+		answers[section] = strings.TrimSpace(submission.View.State.Values[section][section].Value)
+	}
+
+	standup := model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: bot.workspace.WorkspaceID,
+		ChannelID:   submission.View.PrivateMetadata,
+		UserID:      submission.User.ID,
+		Done:        answers["done"],
+		Planned:     answers["planned"],
+		Blockers:    answers["blockers"],
+	}
+
+	project, err := bot.db.SelectProject(standup.ChannelID)
+	if err != nil {
+		return err
+	}
+	//private metadata comes back from the client, the channel is trusted only when it is
+	//a project of the workspace the user submits standups in
+	if project.WorkspaceID != bot.workspace.WorkspaceID {
+		return errors.New("channel is not a workspace project")
+	}
+	_, err = bot.db.FindStansuperByUserID(standup.UserID, standup.ChannelID)
+	if err != nil {
+		return errors.New("user does not submit standups in the channel")
+	}
+	project = bot.standuperProject(project, standup.UserID)
+	project = bot.fillCheckIn(&standup, project)
+	fillLateness(&standup, project)
+	fillStandupDate(&standup, project)
+
+	err = bot.postStandupSummary(&standup)
+	if err != nil {
+		return err
+	}
+
+	standup, err = bot.db.CreateStandup(standup)
+	if err != nil {
+		return err
+	}
+
+	err = bot.trackBlockers(standup)
+	if err != nil {
+		log.Error("trackBlockers failed: ", err)
+	}
+	return nil
+}
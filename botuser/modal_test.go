@@ -0,0 +1,50 @@
+package botuser
+
+import (
+	"testing"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestStandupView(t *testing.T) {
+	b := &Bot{localizer: i18n.NewLocalizer(i18n.NewBundle(language.English), "en")}
+
+	view := b.standupView(model.Project{
+		ChannelID:        "CHAN123",
+		ChannelName:      "general",
+		OptionalSections: "blockers",
+	})
+	assert.Equal(t, "modal", view["type"])
+	assert.Equal(t, standupCallbackID, view["callback_id"])
+	assert.Equal(t, "CHAN123", view["private_metadata"])
+
+	blocks := view["blocks"].([]map[string]interface{})
+	assert.Equal(t, 3, len(blocks))
+	for i, section := range model.StandupSections {
+		assert.Equal(t, section, blocks[i]["block_id"])
+	}
+	assert.Equal(t, false, blocks[0]["optional"])
+	assert.Equal(t, true, blocks[2]["optional"])
+
+	evening := model.CheckIn{Name: "evening", Deadline: "6pm", Questions: "What did you ship today? What is left?"}
+	view = b.standupView(model.Project{
+		ChannelID:   "CHAN123",
+		ChannelName: "general",
+		CheckIns:    []model.CheckIn{evening},
+		CheckInName: "evening",
+	})
+	blocks = view["blocks"].([]map[string]interface{})
+	assert.Equal(t, 2, len(blocks))
+	assert.Equal(t, "done", blocks[0]["block_id"])
+	assert.Equal(t, "What did you ship today?", blocks[0]["label"].(map[string]interface{})["text"])
+	assert.Equal(t, false, blocks[0]["optional"])
+	assert.Equal(t, "planned", blocks[1]["block_id"])
+	assert.Equal(t, "What is left?", blocks[1]["label"].(map[string]interface{})["text"])
+
+	view = b.standupView(model.Project{ChannelName: "very-long-channel-name-for-title"})
+	title := view["title"].(map[string]interface{})["text"].(string)
+	assert.Equal(t, 24, len([]rune(title)))
+}
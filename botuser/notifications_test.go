@@ -50,3 +50,18 @@ func TestFindChannelNonReporters(t *testing.T) {
 	assert.NoError(t, bot.db.DeleteStanduper(standuper.ID))
 	assert.NoError(t, bot.db.DeleteStandup(standup.ID))
 }
+
+func TestShouldSubmitStandupIn(t *testing.T) {
+	project := model.Project{
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+		Holidays:       []model.Holiday{{Date: "2019-10-03", Name: "Tag der Deutschen Einheit"}},
+	}
+
+	//2019-10-03 is thursday
+	assert.Equal(t, true, shouldSubmitStandupIn(&project, time.Date(2019, 10, 2, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, false, shouldSubmitStandupIn(&project, time.Date(2019, 10, 3, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, false, shouldSubmitStandupIn(&project, time.Date(2019, 10, 5, 10, 0, 0, 0, time.UTC)))
+
+	//the weekly report does not count holidays as working days
+	assert.Equal(t, 4, workingDaysBetween(project, time.Date(2019, 9, 29, 0, 0, 0, 0, time.UTC), time.Date(2019, 10, 6, 0, 0, 0, 0, time.UTC)))
+}
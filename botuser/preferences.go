@@ -0,0 +1,213 @@
+package botuser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//notificationPreference returns preference of the user, users who have not
+//chosen anything are mentioned in the channel as before
+func (bot *Bot) notificationPreference(userID string) model.NotificationPreference {
+	preference, err := bot.db.SelectNotificationPreference(bot.workspace.WorkspaceID, userID)
+	if err != nil {
+		return model.NotificationPreference{
+			WorkspaceID: bot.workspace.WorkspaceID,
+			UserID:      userID,
+			Delivery:    model.DeliveryChannel,
+		}
+	}
+	return preference
+}
+
+//headsUpMinutes returns how many minutes before the deadline user is warned
+func (bot *Bot) headsUpMinutes(preference model.NotificationPreference) int64 {
+	if preference.HeadsUpMinutes == 0 {
+		return bot.workspace.ReminderOffset
+	}
+	return preference.HeadsUpMinutes
+}
+
+//splitByDelivery splits users into the ones to mention in the channel and the ones to message directly
+func (bot *Bot) splitByDelivery(users []string) (inChannel, inDM []string) {
+	inChannel, inDM = []string{}, []string{}
+	for _, user := range users {
+		if user == "" {
+			continue
+		}
+		preference := bot.notificationPreference(user)
+		if preference.InChannel() {
+			inChannel = append(inChannel, user)
+		}
+		if preference.InDM() {
+			inDM = append(inDM, user)
+		}
+	}
+	return inChannel, inDM
+}
+
+//sendDirectReminders sends personal reminder about the project standup to every user
+func (bot *Bot) sendDirectReminders(users []string, project model.Project, message *i18n.Message, minutes int64) {
+	for _, user := range users {
+		text, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: message,
+			TemplateData: map[string]interface{}{
+				"Channel": project.ChannelName,
+				"Minutes": minutes,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+
+		err = bot.send(&Message{
+			Type: "direct",
+			User: user,
+			Text: bot.checkInText(project, text),
+		})
+		if err != nil {
+			log.Error("SendUserMessage failed: ", err)
+		}
+	}
+}
+
+//headsUpNonReporters warns standupers who have not submitted standup yet about
+//the coming deadline, each at the time they have chosen
+func (bot *Bot) headsUpNonReporters(project model.Project, now time.Time) error {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	excuses := map[string][]model.Excuse{}
+	inChannel := map[int64][]string{}
+	for _, standuper := range standupers {
+		local := bot.standuperProject(project, standuper.UserID)
+		deadline, ok := standupDeadline(local, now)
+		if !ok {
+			continue
+		}
+		localNow := now.In(deadline.Location())
+
+		preference := bot.notificationPreference(standuper.UserID)
+		minutes := bot.headsUpMinutes(preference)
+
+		warningTime := deadline.Add(-time.Duration(minutes) * time.Minute)
+		if warningTime.Hour() != localNow.Hour() || warningTime.Minute() != localNow.Minute() {
+			continue
+		}
+
+		today := standupDate(local, localNow)
+		if bot.onLeave(standuper.UserID, today) {
+			continue
+		}
+
+		if _, loaded := excuses[today]; !loaded {
+			excuses[today] = bot.projectExcuses(project, today)
+		}
+		if _, skipped := skippedBy(excuses[today], standuper.UserID); skipped || snoozedBy(excuses[today], standuper.UserID, now) {
+			continue
+		}
+
+		if bot.submittedStandupToday(standuper.UserID, standuper.ChannelID, project.CheckInName) {
+			continue
+		}
+
+		if preference.InChannel() {
+			inChannel[minutes] = append(inChannel[minutes], standuper.UserID)
+		}
+		if preference.InDM() {
+			bot.sendDirectReminders([]string{standuper.UserID}, project, &i18n.Message{
+				ID:    "dmWarnNonReporter",
+				Other: "Standup in #{{.Channel}} is due in {{.Minutes}} minutes, do not forget to submit it",
+			}, minutes)
+		}
+	}
+
+	for minutes, users := range inChannel {
+		message, err := bot.composeWarnMessage(users, minutes)
+		if err != nil {
+			return fmt.Errorf("could not compose Warn Message: %v", err)
+		}
+
+		bot.send(&Message{
+			Type:    "message",
+			Channel: project.ChannelID,
+			Text:    bot.checkInText(project, message),
+		})
+	}
+
+	return nil
+}
+
+//modifyNotifications shows or changes how the user is reminded about standups
+func (bot *Bot) modifyNotifications(command slack.SlashCommand) string {
+	preference := bot.notificationPreference(command.UserID)
+
+	for _, arg := range strings.Fields(strings.ToLower(command.Text)) {
+		switch arg {
+		case model.DeliveryChannel, model.DeliveryDM, model.DeliveryBoth:
+			preference.Delivery = arg
+			continue
+		}
+
+		minutes, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSuffix(arg, "min"), "m"), 10, 64)
+		if err != nil || minutes < 0 {
+			wrongNotifications, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "wrongNotifications",
+					Other: "Use `/notifications channel|dm|both [minutes]`, e.g. `/notifications dm 15` to get direct messages 15 minutes before deadlines",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return wrongNotifications
+		}
+		preference.HeadsUpMinutes = minutes
+	}
+
+	if strings.TrimSpace(command.Text) != "" {
+		var err error
+		if preference.ID == 0 {
+			preference.CreatedAt = time.Now().Unix()
+			preference, err = bot.db.CreateNotificationPreference(preference)
+		} else {
+			preference, err = bot.db.UpdateNotificationPreference(preference)
+		}
+		if err != nil {
+			log.Error(err)
+			failedNotifications, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "failedNotifications",
+					Other: "Could not change notification preferences",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return failedNotifications
+		}
+	}
+
+	showNotifications, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "showNotifications",
+			Other: "You are reminded about standups in: {{.Delivery}}, {{.Minutes}} minutes before deadlines",
+		},
+		TemplateData: map[string]interface{}{
+			"Delivery": preference.Delivery,
+			"Minutes":  bot.headsUpMinutes(preference),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return showNotifications
+}
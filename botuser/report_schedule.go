@@ -0,0 +1,137 @@
+package botuser
+
+import (
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//reportDestinations keeps report attachments by channel they are posted to,
+//in the order channels get their first attachments
+type reportDestinations struct {
+	channels    []string
+	attachments map[string][]slack.Attachment
+}
+
+func (d *reportDestinations) add(channelID string, attachments []slack.Attachment) {
+	if d.attachments == nil {
+		d.attachments = map[string][]slack.Attachment{}
+	}
+	if _, ok := d.attachments[channelID]; !ok {
+		d.channels = append(d.channels, channelID)
+	}
+	d.attachments[channelID] = append(d.attachments[channelID], attachments...)
+}
+
+//sendReports posts reports to every destination, the last failure is returned
+func (bot *Bot) sendReports(destinations reportDestinations, header string) error {
+	var err error
+	for _, channelID := range destinations.channels {
+		if channelID == "" {
+			log.Warning("reports are not sent, workspace has no reporting channel")
+			continue
+		}
+		sendErr := bot.send(&Message{
+			Type:        "message",
+			Channel:     channelID,
+			Text:        header,
+			Attachments: destinations.attachments[channelID],
+		})
+		if sendErr != nil {
+			log.Errorf("send report to %v failed: %v", channelID, sendErr)
+			err = sendErr
+		}
+	}
+	return err
+}
+
+//reportingChannelID returns channel reports on the project go to: its own reporting channel
+//or the one of the workspace. Tracked channels may be given by name, others by ID
+func (bot *Bot) reportingChannelID(project model.Project, channels []model.Project) string {
+	reportingChannel := project.ReportingChannel
+	if reportingChannel == "" {
+		reportingChannel = bot.workspace.ReportingChannel
+	}
+	for _, ch := range channels {
+		if ch.ChannelName == reportingChannel || ch.ChannelID == reportingChannel {
+			return ch.ChannelID
+		}
+	}
+	return reportingChannel
+}
+
+//ownReportSchedule tells if reports of the kind on the project have their own schedule
+//instead of the workspace one
+func ownReportSchedule(project model.Project, kind string) bool {
+	if kind == model.JobWeeklyReport && project.WeeklyReportDay != "" {
+		return true
+	}
+	return project.ReportingTime != ""
+}
+
+//reportsEnabled tells if the project opted in reports of the kind
+func reportsEnabled(project model.Project, kind string) bool {
+	if kind == model.JobWeeklyReport {
+		return !project.WeeklyReportsDisabled
+	}
+	return !project.DailyReportsDisabled
+}
+
+//weeklyReportDays returns filter of days weekly reports on the project are due, sundays by default
+func weeklyReportDays(project model.Project) func(day time.Time) bool {
+	schedule, err := model.ParseSchedule(project.WeeklyReportDay)
+	if project.WeeklyReportDay == "" || err != nil {
+		return func(day time.Time) bool {
+			return day.Weekday() == time.Sunday
+		}
+	}
+	return schedule.Includes
+}
+
+//projectReportTimes returns moments within [from, to) reports of the kind on the project are due at.
+//Reporting time of the project, or of the workspace, is taken in the project timezone
+func (bot *Bot) projectReportTimes(project model.Project, kind string, from, to time.Time) []time.Time {
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		return nil
+	}
+
+	reportingTime := project.ReportingTime
+	if reportingTime == "" {
+		reportingTime = bot.workspace.ReportingTime
+	}
+
+	if kind == model.JobWeeklyReport {
+		return zoneTimes(reportingTime, loc, from, to, weeklyReportDays(project))
+	}
+	return zoneTimes(reportingTime, loc, from, to, nil)
+}
+
+//reportedProjects returns projects the report job is about: the project of the job,
+//or projects of the workspace that follow the workspace schedule
+func (bot *Bot) reportedProjects(job model.Job) ([]model.Project, error) {
+	if job.ChannelID != "" {
+		project, err := bot.db.SelectProject(job.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+		if !reportsEnabled(project, job.Kind) {
+			return nil, nil
+		}
+		return []model.Project{project}, nil
+	}
+
+	projects, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	reported := []model.Project{}
+	for _, project := range projects {
+		if reportsEnabled(project, job.Kind) && !ownReportSchedule(project, job.Kind) {
+			reported = append(reported, project)
+		}
+	}
+	return reported, nil
+}
@@ -0,0 +1,55 @@
+package botuser
+
+import (
+	"testing"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportingChannelID(t *testing.T) {
+	bot := &Bot{workspace: &model.Workspace{ReportingChannel: "reports"}}
+	channels := []model.Project{
+		{ChannelID: "CREPORTS", ChannelName: "reports"},
+		{ChannelID: "CCLIENT", ChannelName: "client"},
+	}
+
+	assert.Equal(t, "CREPORTS", bot.reportingChannelID(model.Project{ChannelID: "CBACKEND"}, channels))
+	assert.Equal(t, "CCLIENT", bot.reportingChannelID(model.Project{ReportingChannel: "client"}, channels))
+	//shared channels the bot does not track are given by ID
+	assert.Equal(t, "CSHARED", bot.reportingChannelID(model.Project{ReportingChannel: "CSHARED"}, channels))
+
+	bot.workspace.ReportingChannel = ""
+	assert.Equal(t, "", bot.reportingChannelID(model.Project{}, channels))
+}
+
+func TestOwnReportSchedule(t *testing.T) {
+	project := model.Project{}
+	assert.Equal(t, false, ownReportSchedule(project, model.JobDailyReport))
+	assert.Equal(t, false, ownReportSchedule(project, model.JobWeeklyReport))
+	assert.Equal(t, true, reportsEnabled(project, model.JobDailyReport))
+	assert.Equal(t, true, reportsEnabled(project, model.JobWeeklyReport))
+
+	//own weekly day does not move daily reports
+	project.WeeklyReportDay = "friday"
+	assert.Equal(t, false, ownReportSchedule(project, model.JobDailyReport))
+	assert.Equal(t, true, ownReportSchedule(project, model.JobWeeklyReport))
+
+	project.ReportingTime = "9:00"
+	assert.Equal(t, true, ownReportSchedule(project, model.JobDailyReport))
+
+	project.DailyReportsDisabled = true
+	assert.Equal(t, false, reportsEnabled(project, model.JobDailyReport))
+	assert.Equal(t, true, reportsEnabled(project, model.JobWeeklyReport))
+}
+
+func TestReportDestinations(t *testing.T) {
+	destinations := reportDestinations{}
+	destinations.add("CREPORTS", []slack.Attachment{{Text: "backend"}})
+	destinations.add("CCLIENT", []slack.Attachment{{Text: "client"}})
+	destinations.add("CREPORTS", []slack.Attachment{{Text: "frontend"}})
+
+	assert.Equal(t, []string{"CREPORTS", "CCLIENT"}, destinations.channels)
+	assert.Equal(t, []slack.Attachment{{Text: "backend"}, {Text: "frontend"}}, destinations.attachments["CREPORTS"])
+}
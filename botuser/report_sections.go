@@ -0,0 +1,190 @@
+package botuser
+
+import (
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	log "github.com/sirupsen/logrus"
+)
+
+//ReportSection is a part of report entries on standupers. It gathers data on the standuper
+//for the period of the entry, scores it and renders the text of the section
+type ReportSection interface {
+	Report(bot *Bot, entry *ReportEntry) SectionReport
+}
+
+//SectionReport is what a section adds to the report entry: text, empty if there is
+//nothing to show, points the standuper got and the most points the section gives
+type SectionReport struct {
+	Text      string
+	Points    int
+	MaxPoints int
+}
+
+//reportSections are sections workspaces choose by name in their settings
+var reportSections = map[string]ReportSection{
+	model.ReportSectionWorklogs: worklogsReport{},
+	model.ReportSectionCommits:  commitsReport{},
+	model.ReportSectionStandup:  standupReport{},
+}
+
+//ReportEntry is a standuper of the project reported on for the period from one day to another.
+//Data sections share, like collector data, is gathered once per entry
+type ReportEntry struct {
+	Standuper model.Standuper
+	Project   model.Project
+	From      time.Time
+	To        time.Time
+	//Daily entries judge the single day the way daily reports do, others sum the period up
+	Daily bool
+	//Skipped holds dates in the period the standuper skipped
+	Skipped map[string]bool
+
+	collected              bool
+	collectorData          CollectorData
+	collectorDataInProject CollectorData
+	collectorError         error
+
+	counted bool
+	count   standupCount
+}
+
+//collector returns collector data on the standuper for the period of the entry
+func (entry *ReportEntry) collector(bot *Bot) (CollectorData, CollectorData, error) {
+	if !entry.collected {
+		entry.collectorData, entry.collectorDataInProject, entry.collectorError = bot.GetCollectorDataOnMember(entry.Standuper, entry.From, entry.To)
+		entry.collected = true
+	}
+	return entry.collectorData, entry.collectorDataInProject, entry.collectorError
+}
+
+//standups counts standups of the standuper over the period of the entry
+func (entry *ReportEntry) standups(bot *Bot) standupCount {
+	if entry.counted {
+		return entry.count
+	}
+	entry.counted = true
+
+	standups, err := bot.db.ListStanduperStandups(entry.Standuper.UserID, entry.Standuper.ChannelID)
+	if err != nil {
+		log.Error("ListStanduperStandups failed: ", err)
+	}
+	absences, err := bot.db.ListUserAbsences(bot.workspace.WorkspaceID, entry.Standuper.UserID, entry.From.Format("2006-01-02"))
+	if err != nil {
+		log.Error("ListUserAbsences failed: ", err)
+	}
+	entry.count = countStandups(entry.Project, standups, absences, entry.Skipped, entry.From, entry.To)
+	return entry.count
+}
+
+//scoring returns rules worklogs and commits of the standuper are judged by
+func (entry *ReportEntry) scoring(bot *Bot) model.ScoringRules {
+	return bot.workspace.Scoring.RulesFor(entry.Standuper.UserID, entry.Standuper.Role)
+}
+
+//projectWorklogs returns time the standuper logged in the project, report entries are sorted by it
+func (entry *ReportEntry) projectWorklogs() int {
+	if !entry.collected || entry.collectorError != nil {
+		return 0
+	}
+	return entry.collectorDataInProject.Worklogs
+}
+
+//workspaceReportSections returns sections the workspace has chosen in their order
+func (bot *Bot) workspaceReportSections() []ReportSection {
+	sections := []ReportSection{}
+	for _, name := range bot.workspace.ReportSectionNames() {
+		section, ok := reportSections[name]
+		if !ok {
+			log.Warningf("unknown report section %v", name)
+			continue
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+//reportOn runs sections on the entry and returns text of the entry, its points and the most
+//points it could get
+func (bot *Bot) reportOn(entry *ReportEntry, sections []ReportSection) (string, int, int) {
+	var text string
+	var points, maxPoints int
+	for _, section := range sections {
+		report := section.Report(bot, entry)
+		text += report.Text
+		points += report.Points
+		maxPoints += report.MaxPoints
+	}
+	return text, points, maxPoints
+}
+
+type worklogsReport struct{}
+
+//Report judges time logged on the day, or over the submission days of the period
+func (worklogsReport) Report(bot *Bot, entry *ReportEntry) SectionReport {
+	thresholds := entry.scoring(bot).DailyWorklogs
+	if !entry.Daily {
+		thresholds = entry.scoring(bot).WeeklyWorklogs
+	}
+	maxPoints := thresholds.MaxPoints()
+
+	dataOnUser, dataOnUserInProject, err := entry.collector(bot)
+	if err != nil {
+		return SectionReport{Points: maxPoints, MaxPoints: maxPoints}
+	}
+
+	if entry.Daily {
+		text, points := bot.processWorklogs(dataOnUser.Worklogs, dataOnUserInProject.Worklogs, thresholds)
+		return SectionReport{Text: text, Points: points, MaxPoints: maxPoints}
+	}
+
+	days := entry.standups(bot).Expected / len(checkInProjects(entry.Project))
+	emoji, points := rangeWorklogsPoints(dataOnUser.Worklogs, days, thresholds)
+	return SectionReport{
+		Text:      bot.worklogsText(dataOnUser.Worklogs, dataOnUserInProject.Worklogs, emoji),
+		Points:    points,
+		MaxPoints: maxPoints,
+	}
+}
+
+type commitsReport struct{}
+
+//Report counts commits pushed to the project, project managers and designers are not expected to commit
+func (commitsReport) Report(bot *Bot, entry *ReportEntry) SectionReport {
+	thresholds := entry.scoring(bot).Commits
+	maxPoints := thresholds.MaxPoints()
+
+	if entry.Standuper.Role == "pm" || entry.Standuper.Role == "designer" {
+		return SectionReport{Points: maxPoints, MaxPoints: maxPoints}
+	}
+
+	dataOnUser, dataOnUserInProject, err := entry.collector(bot)
+	if err != nil {
+		return SectionReport{Points: maxPoints, MaxPoints: maxPoints}
+	}
+
+	if entry.Daily {
+		text, points := bot.processCommits(dataOnUser.Commits, dataOnUserInProject.Commits, thresholds)
+		return SectionReport{Text: text, Points: points, MaxPoints: maxPoints}
+	}
+
+	emoji, points := thresholds.Judge(dataOnUserInProject.Commits)
+	return SectionReport{
+		Text:      bot.commitsText(dataOnUserInProject.Commits, emoji),
+		Points:    points,
+		MaxPoints: maxPoints,
+	}
+}
+
+type standupReport struct{}
+
+//Report tells if the standup of the day is submitted, or how many standups of the period are
+func (standupReport) Report(bot *Bot, entry *ReportEntry) SectionReport {
+	if entry.Daily {
+		text, points := bot.processStandup(entry.Standuper)
+		return SectionReport{Text: text, Points: points, MaxPoints: 1}
+	}
+
+	text, points := bot.rangeStandupsText(entry.standups(bot))
+	return SectionReport{Text: text, Points: points, MaxPoints: 1}
+}
@@ -0,0 +1,70 @@
+package botuser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+type fixedSection SectionReport
+
+func (s fixedSection) Report(bot *Bot, entry *ReportEntry) SectionReport {
+	return SectionReport(s)
+}
+
+func TestWorkspaceReportSections(t *testing.T) {
+	bot := &Bot{workspace: &model.Workspace{}}
+	assert.Equal(t, []ReportSection{worklogsReport{}, commitsReport{}, standupReport{}}, bot.workspaceReportSections())
+
+	bot.workspace.ReportSections = "standup, commits"
+	assert.Equal(t, []ReportSection{standupReport{}, commitsReport{}}, bot.workspaceReportSections())
+
+	//sections removed from the bot are left out
+	bot.workspace.ReportSections = "standup,karma"
+	assert.Equal(t, []ReportSection{standupReport{}}, bot.workspaceReportSections())
+}
+
+func TestReportOn(t *testing.T) {
+	bot := &Bot{}
+	sections := []ReportSection{
+		fixedSection{Text: "worklogs ", Points: 2, MaxPoints: 2},
+		fixedSection{MaxPoints: 1},
+		fixedSection{Text: "standup ", Points: 1, MaxPoints: 1},
+	}
+	text, points, maxPoints := bot.reportOn(&ReportEntry{}, sections)
+	assert.Equal(t, "worklogs standup ", text)
+	assert.Equal(t, 3, points)
+	assert.Equal(t, 4, maxPoints)
+}
+
+func TestCommitsReport(t *testing.T) {
+	bot := &Bot{
+		localizer: i18n.NewLocalizer(i18n.NewBundle(language.English), "en"),
+		workspace: &model.Workspace{},
+	}
+
+	//project managers are not expected to commit
+	entry := &ReportEntry{Standuper: model.Standuper{Role: "pm"}}
+	assert.Equal(t, SectionReport{Points: 1, MaxPoints: 1}, commitsReport{}.Report(bot, entry))
+
+	//standupers are not blamed when collector is not available
+	entry = &ReportEntry{collected: true, collectorError: errors.New("collector is down")}
+	assert.Equal(t, SectionReport{Points: 1, MaxPoints: 1}, commitsReport{}.Report(bot, entry))
+	assert.Equal(t, SectionReport{Points: 1, MaxPoints: 1}, worklogsReport{}.Report(bot, entry))
+	assert.Equal(t, 0, entry.projectWorklogs())
+
+	entry = &ReportEntry{collected: true, collectorDataInProject: CollectorData{Commits: 3, Worklogs: 3600}}
+	assert.Equal(t, 1, commitsReport{}.Report(bot, entry).Points)
+	assert.Equal(t, 3600, entry.projectWorklogs())
+
+	//commits weigh as much as the workspace wants
+	bot.workspace.Scoring = model.Scoring{ScoringRules: model.ScoringRules{
+		Commits: model.Thresholds{{From: 0, Emoji: ":shit:"}, {From: 3, Emoji: ":rocket:", Points: 2}},
+	}}
+	assert.Equal(t, 2, commitsReport{}.Report(bot, entry).Points)
+	assert.Equal(t, 2, commitsReport{}.Report(bot, entry).MaxPoints)
+}
@@ -11,9 +11,6 @@ import (
 	"github.com/maddevsio/comedian/model"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/nlopes/slack"
-	"github.com/olebedev/when"
-	"github.com/olebedev/when/rules/en"
-	"github.com/olebedev/when/rules/ru"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -29,57 +26,10 @@ type AttachmentItem struct {
 	Points          int
 }
 
-// CallDisplayYesterdayTeamReport calls displayYesterdayTeamReport
-func (bot *Bot) CallDisplayYesterdayTeamReport() error {
-	if bot.workspace.ReportingTime == "" {
-		return nil
-	}
-
-	w := when.New(nil)
-	w.Add(en.All...)
-	w.Add(ru.All...)
-
-	r, err := w.Parse(bot.workspace.ReportingTime, time.Now())
-	if err != nil {
-		return err
-	}
-
-	if time.Now().Hour() != r.Time.Hour() || time.Now().Minute() != r.Time.Minute() {
-		return nil
-	}
-
-	_, err = bot.displayYesterdayTeamReport()
-	return err
-
-}
-
-// CallDisplayWeeklyTeamReport calls displayWeeklyTeamReport
-func (bot *Bot) CallDisplayWeeklyTeamReport() error {
-	if int(time.Now().Weekday()) != 0 {
-		return nil
-	}
-
-	if bot.workspace.ReportingTime == "" {
-		return nil
-	}
-
-	w := when.New(nil)
-	w.Add(en.All...)
-	w.Add(ru.All...)
-
-	r, err := w.Parse(bot.workspace.ReportingTime, time.Now())
-
-	if time.Now().Hour() != r.Time.Hour() || time.Now().Minute() != r.Time.Minute() {
-		return nil
-	}
-
-	_, err = bot.displayWeeklyTeamReport()
-	return err
-}
-
-// displayYesterdayTeamReport generates report on users who submit standups
-func (bot *Bot) displayYesterdayTeamReport() (string, error) {
+// displayYesterdayTeamReport generates report on users who submit standups of the projects
+func (bot *Bot) displayYesterdayTeamReport(projects []model.Project) (string, error) {
 	var allReports []slack.Attachment
+	destinations := reportDestinations{}
 
 	channels, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
 	if err != nil {
@@ -96,7 +46,9 @@ func (bot *Bot) displayYesterdayTeamReport() (string, error) {
 		log.Error(err)
 	}
 
-	for _, channel := range channels {
+	sections := bot.workspaceReportSections()
+
+	for _, channel := range projects {
 
 		var attachments []slack.Attachment
 		var attachmentsPull []AttachmentItem
@@ -111,93 +63,46 @@ func (bot *Bot) displayYesterdayTeamReport() (string, error) {
 			continue
 		}
 
-		for _, standuper := range standupers {
-			var attachment slack.Attachment
-			var attachmentFields []slack.AttachmentField
-			var worklogs, commits, standup string
-			var worklogsPoints, commitsPoints, standupPoints int
-
-			dataOnUser, dataOnUserInProject, collectorError := bot.GetCollectorDataOnMember(standuper, time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, -1))
+		excuses := bot.projectExcuses(channel, yesterdayIn(channel))
+		yesterday := yesterdayTime(channel)
 
-			if collectorError == nil {
-				worklogs, worklogsPoints = bot.processWorklogs(dataOnUser.Worklogs, dataOnUserInProject.Worklogs)
-				commits, commitsPoints = bot.processCommits(dataOnUser.Commits, dataOnUserInProject.Commits)
-			}
-
-			if standuper.Role == "pm" || standuper.Role == "designer" {
-				commits = ""
-				commitsPoints++
+		for _, standuper := range standupers {
+			entry := &ReportEntry{
+				Standuper: standuper,
+				Project:   channel,
+				From:      yesterday,
+				To:        yesterday,
+				Daily:     true,
 			}
 
-			if collectorError != nil {
-				worklogs = ""
-				worklogsPoints++
-				commits = ""
-				commitsPoints++
+			var fieldValue string
+			var points, maxPoints int
+			//standupers who skipped the day are excused from everything
+			if excuse, skipped := skippedBy(excuses, standuper.UserID); skipped {
+				fieldValue, points, maxPoints = bot.skippedText(excuse), 1, 1
+			} else {
+				fieldValue, points, maxPoints = bot.reportOn(entry, sections)
 			}
 
-			standup, standupPoints = bot.processStandup(standuper)
-
-			fieldValue := worklogs + commits + standup
-
 			//if there is nothing to show, do not create attachment
 			if fieldValue == "" {
 				log.Warningf("Nothing to show... skip standuper! %v", standuper)
 				continue
 			}
 
-			attachmentFields = append(attachmentFields, slack.AttachmentField{
-				Value: fieldValue,
-				Short: false,
-			})
-
-			points := worklogsPoints + commitsPoints + standupPoints
-
-			//attachment text will be depend on worklogsPoints,commitsPoints and standupPoints
-			if points >= 3 {
-				notTagStanduper, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-					DefaultMessage: &i18n.Message{
-						ID:    "notTagStanduper",
-						Other: "",
-					},
-					TemplateData: map[string]interface{}{"user": standuper.RealName, "channel": channel.ChannelName},
-				})
-				if err != nil {
-					log.Error(err)
-				}
-				attachment.Text = notTagStanduper
-			} else {
-				tagStanduper, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-					DefaultMessage: &i18n.Message{
-						ID:    "tagStanduper",
-						Other: "",
-					},
-					TemplateData: map[string]interface{}{"user": standuper.UserID, "channel": channel.ChannelName},
-				})
-				if err != nil {
-					log.Error(err)
-				}
-				attachment.Text = tagStanduper
-			}
-
-			switch points {
-			case 0:
-				attachment.Color = "danger"
-			case 1, 2:
-				attachment.Color = "warning"
-			case 3:
-				attachment.Color = "good"
-			}
-
+			attachment := bot.reportAttachment(standuper, channel, points, maxPoints)
 			if int(time.Now().Weekday()) == 0 || int(time.Now().Weekday()) == 1 {
 				attachment.Color = "good"
 			}
 
-			attachment.Fields = attachmentFields
+			attachment.Fields = []slack.AttachmentField{{
+				Value: fieldValue,
+				Short: false,
+			}}
 
 			item := AttachmentItem{
 				SlackAttachment: attachment,
-				Points:          dataOnUserInProject.Worklogs,
+				Points:          entry.projectWorklogs(),
 			}
 
 			attachmentsPull = append(attachmentsPull, item)
@@ -221,35 +126,24 @@ func (bot *Bot) displayYesterdayTeamReport() (string, error) {
 		}
 
 		allReports = append(allReports, attachments...)
+		destinations.add(bot.reportingChannelID(channel, channels), attachments)
 	}
 
 	if len(allReports) == 0 {
 		return "", nil
 	}
 
-	var reportingChannelID string
-
-	for _, ch := range channels {
-		if (ch.ChannelName == bot.workspace.ReportingChannel && ch.WorkspaceID == bot.workspace.WorkspaceID) || (ch.ChannelID == bot.workspace.ReportingChannel && ch.WorkspaceID == bot.workspace.WorkspaceID) {
-			reportingChannelID = ch.ChannelID
-		}
-	}
-
-	err = bot.send(&Message{
-		Type:        "message",
-		Channel:     reportingChannelID,
-		Text:        reportHeader,
-		Attachments: allReports,
-	})
+	err = bot.sendReports(destinations, reportHeader)
 
 	return fmt.Sprintf(reportHeader, allReports), err
 }
 
-// displayWeeklyTeamReport generates report on users who submit standups
-func (bot *Bot) displayWeeklyTeamReport() (string, error) {
+// displayWeeklyTeamReport generates report on users who submit standups of the projects
+func (bot *Bot) displayWeeklyTeamReport(projects []model.Project) (string, error) {
 	var allReports []slack.Attachment
+	destinations := reportDestinations{}
 
-	channels, err := bot.db.ListProjects()
+	channels, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
 	if err != nil {
 		return "", err
 	}
@@ -264,7 +158,7 @@ func (bot *Bot) displayWeeklyTeamReport() (string, error) {
 		log.Error(err)
 	}
 
-	for _, channel := range channels {
+	for _, channel := range projects {
 		var attachmentsPull []AttachmentItem
 		var attachments []slack.Attachment
 
@@ -279,80 +173,71 @@ func (bot *Bot) displayWeeklyTeamReport() (string, error) {
 		}
 
 		for _, standuper := range standupers {
-			var attachment slack.Attachment
-			var attachmentFields []slack.AttachmentField
 			var worklogs, commits string
 			var worklogsPoints, commitsPoints int
 
+			rules := bot.workspace.Scoring.RulesFor(standuper.UserID, standuper.Role)
+			maxWorklogsPoints, maxCommitsPoints := rules.WeeklyWorklogs.MaxPoints(), rules.Commits.MaxPoints()
+
 			dataOnUser, dataOnUserInProject, collectorError := bot.GetCollectorDataOnMember(standuper, time.Now().AddDate(0, 0, -7), time.Now().AddDate(0, 0, -1))
 
 			if collectorError == nil {
-				worklogs, worklogsPoints = bot.processWeeklyWorklogs(dataOnUser.Worklogs, dataOnUserInProject.Worklogs)
-				commits, commitsPoints = bot.processCommits(dataOnUser.Commits, dataOnUserInProject.Commits)
+				worklogs, worklogsPoints = bot.processWeeklyWorklogs(dataOnUser.Worklogs, dataOnUserInProject.Worklogs, rules.WeeklyWorklogs)
+				commits, commitsPoints = bot.processCommits(dataOnUser.Commits, dataOnUserInProject.Commits, rules.Commits)
 			}
 
 			if standuper.Role == "pm" || standuper.Role == "designer" {
 				commits = ""
-				commitsPoints++
+				commitsPoints = maxCommitsPoints
 			}
 
 			if collectorError != nil {
 				worklogs = ""
-				worklogsPoints++
+				worklogsPoints = maxWorklogsPoints
 				commits = ""
-				commitsPoints++
+				commitsPoints = maxCommitsPoints
 			}
 
-			fieldValue := worklogs + commits
-
-			//if there is nothing to show, do not create attachment
-			if fieldValue == "" {
-				continue
+			weekStart, weekEnd := time.Now().AddDate(0, 0, -7), time.Now().AddDate(0, 0, -1)
+			absences, err := bot.db.ListUserAbsences(bot.workspace.WorkspaceID, standuper.UserID, weekStart.Format("2006-01-02"))
+			if err != nil {
+				log.Error("ListUserAbsences failed: ", err)
 			}
-
-			attachmentFields = append(attachmentFields, slack.AttachmentField{
-				Value: fieldValue,
-				Short: false,
-			})
-
-			points := worklogsPoints + commitsPoints
-
-			if points >= 2 {
-				notTagStanduper, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			leave := ""
+			if days := absentDays(channel, absences, weekStart, weekEnd); days > 0 {
+				onLeaveDays, err := bot.localizer.Localize(&i18n.LocalizeConfig{
 					DefaultMessage: &i18n.Message{
-						ID:    "notTagStanduper",
-						Other: "",
+						ID:    "onLeaveDays",
+						One:   "on leave {{.Days}} day :palm_tree: ",
+						Other: "on leave {{.Days}} days :palm_tree: ",
 					},
-					TemplateData: map[string]interface{}{"user": standuper.RealName, "channel": channel.ChannelName},
+					PluralCount:  days,
+					TemplateData: map[string]interface{}{"Days": days},
 				})
 				if err != nil {
 					log.Error(err)
 				}
-				attachment.Text = notTagStanduper
-			} else {
-				tagStanduper, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-					DefaultMessage: &i18n.Message{
-						ID:    "tagStanduper",
-						Other: "",
-					},
-					TemplateData: map[string]interface{}{"user": standuper.UserID, "channel": channel.ChannelName},
-				})
-				if err != nil {
-					log.Error(err)
+				leave = onLeaveDays
+
+				//people on leave the whole week are not blamed for missing worklogs and commits
+				if days >= workingDaysBetween(channel, weekStart.AddDate(0, 0, -1), weekEnd) {
+					worklogs, commits = "", ""
+					worklogsPoints, commitsPoints = maxWorklogsPoints, maxCommitsPoints
 				}
-				attachment.Text = tagStanduper
 			}
 
-			switch points {
-			case 0:
-				attachment.Color = "danger"
-			case 1:
-				attachment.Color = "warning"
-			case 2:
-				attachment.Color = "good"
+			fieldValue := worklogs + commits + leave
+
+			//if there is nothing to show, do not create attachment
+			if fieldValue == "" {
+				continue
 			}
 
-			attachment.Fields = attachmentFields
+			attachment := bot.reportAttachment(standuper, channel, worklogsPoints+commitsPoints, maxWorklogsPoints+maxCommitsPoints)
+			attachment.Fields = []slack.AttachmentField{{
+				Value: fieldValue,
+				Short: false,
+			}}
 
 			item := AttachmentItem{
 				SlackAttachment: attachment,
@@ -380,64 +265,20 @@ func (bot *Bot) displayWeeklyTeamReport() (string, error) {
 			}
 		}
 		allReports = append(allReports, attachments...)
+		destinations.add(bot.reportingChannelID(channel, channels), attachments)
 	}
 
 	if len(allReports) == 0 {
 		return "", nil
 	}
 
-	var reportingChannelID string
-
-	for _, ch := range channels {
-		if (ch.ChannelName == bot.workspace.ReportingChannel && ch.WorkspaceID == bot.workspace.WorkspaceID) || (ch.ChannelID == bot.workspace.ReportingChannel && ch.WorkspaceID == bot.workspace.WorkspaceID) {
-			reportingChannelID = ch.ChannelID
-		}
-	}
-
-	err = bot.send(&Message{
-		Type:        "message",
-		Channel:     reportingChannelID,
-		Text:        reportHeaderWeekly,
-		Attachments: allReports,
-	})
+	err = bot.sendReports(destinations, reportHeaderWeekly)
 
 	return fmt.Sprintf(reportHeaderWeekly, allReports), err
 }
 
-func (bot *Bot) processWorklogs(totalWorklogs, projectWorklogs int) (string, int) {
-
-	var points int
-	worklogsEmoji := ""
-
-	w := totalWorklogs / 3600
-	switch {
-	case w < 3:
-		worklogsEmoji = ":angry:"
-	case w >= 3 && w < 7:
-		worklogsEmoji = ":disappointed:"
-	case w >= 7 && w < 9:
-		worklogsEmoji = ":wink:"
-		points++
-	case w >= 9:
-		worklogsEmoji = ":sunglasses:"
-		points++
-	}
-
-	worklogsTime := SecondsToHuman(totalWorklogs)
-
-	if totalWorklogs != projectWorklogs {
-		var err error
-		worklogsTime, err = bot.localizer.Localize(&i18n.LocalizeConfig{
-			DefaultMessage: &i18n.Message{
-				ID:    "worklogsTime",
-				Other: "",
-			},
-			TemplateData: map[string]interface{}{"projectWorklogs": SecondsToHuman(projectWorklogs), "totalWorklogs": SecondsToHuman(totalWorklogs)},
-		})
-		if err != nil {
-			log.Error(err)
-		}
-	}
+func (bot *Bot) processWorklogs(totalWorklogs, projectWorklogs int, thresholds model.Thresholds) (string, int) {
+	worklogsEmoji, points := thresholds.Judge(totalWorklogs / 3600)
 
 	if int(time.Now().Weekday()) == 0 || int(time.Now().Weekday()) == 1 {
 		worklogsEmoji = ""
@@ -446,34 +287,16 @@ func (bot *Bot) processWorklogs(totalWorklogs, projectWorklogs int) (string, int
 		}
 	}
 
-	worklogsTranslation, err := bot.localizer.Localize(&i18n.LocalizeConfig{
-		DefaultMessage: &i18n.Message{
-			ID:    "worklogsTranslation",
-			Other: "",
-		},
-		TemplateData: map[string]interface{}{"worklogsTime": worklogsTime, "worklogsEmoji": worklogsEmoji},
-	})
-	if err != nil {
-		log.Error(err)
-	}
-	return worklogsTranslation, points
+	return bot.worklogsText(totalWorklogs, projectWorklogs, worklogsEmoji), points
 }
 
-func (bot *Bot) processWeeklyWorklogs(totalWorklogs, projectWorklogs int) (string, int) {
-	var points int
-	worklogsEmoji := ""
-
-	w := totalWorklogs / 3600
-	switch {
-	case w < 31:
-		worklogsEmoji = ":disappointed:"
-	case w >= 31 && w < 35:
-		worklogsEmoji = ":wink:"
-		points++
-	case w >= 35:
-		worklogsEmoji = ":sunglasses:"
-		points++
-	}
+func (bot *Bot) processWeeklyWorklogs(totalWorklogs, projectWorklogs int, thresholds model.Thresholds) (string, int) {
+	worklogsEmoji, points := thresholds.Judge(totalWorklogs / 3600)
+	return bot.worklogsText(totalWorklogs, projectWorklogs, worklogsEmoji), points
+}
+
+//worklogsText shows logged time, time logged in the project is shown separately when it differs
+func (bot *Bot) worklogsText(totalWorklogs, projectWorklogs int, worklogsEmoji string) string {
 	worklogsTime := SecondsToHuman(totalWorklogs)
 
 	if totalWorklogs != projectWorklogs {
@@ -500,22 +323,11 @@ func (bot *Bot) processWeeklyWorklogs(totalWorklogs, projectWorklogs int) (strin
 	if err != nil {
 		log.Error(err)
 	}
-
-	return worklogsTranslation, points
+	return worklogsTranslation
 }
 
-func (bot *Bot) processCommits(totalCommits, projectCommits int) (string, int) {
-	var points int
-	commitsEmoji := ""
-
-	c := projectCommits
-	switch {
-	case c == 0:
-		commitsEmoji = ":shit:"
-	case c > 0:
-		commitsEmoji = ":wink:"
-		points++
-	}
+func (bot *Bot) processCommits(totalCommits, projectCommits int, thresholds model.Thresholds) (string, int) {
+	commitsEmoji, points := thresholds.Judge(projectCommits)
 
 	if int(time.Now().Weekday()) == 0 || int(time.Now().Weekday()) == 1 {
 		commitsEmoji = ""
@@ -524,6 +336,10 @@ func (bot *Bot) processCommits(totalCommits, projectCommits int) (string, int) {
 		}
 	}
 
+	return bot.commitsText(projectCommits, commitsEmoji), points
+}
+
+func (bot *Bot) commitsText(projectCommits int, commitsEmoji string) string {
 	commitsTranslation, err := bot.localizer.Localize(&i18n.LocalizeConfig{
 		DefaultMessage: &i18n.Message{
 			ID:    "commitsTranslation",
@@ -534,27 +350,80 @@ func (bot *Bot) processCommits(totalCommits, projectCommits int) (string, int) {
 	if err != nil {
 		log.Error(err)
 	}
-	return commitsTranslation, points
+	return commitsTranslation
+}
+
+//yesterdayTime returns the same moment of the previous day in the project timezone
+func yesterdayTime(project model.Project) time.Time {
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		loc = time.Local
+	}
+	return time.Now().In(loc).AddDate(0, 0, -1)
+}
+
+//yesterdayIn returns date of the previous day in the project timezone
+func yesterdayIn(project model.Project) string {
+	return yesterdayTime(project).Format("2006-01-02")
 }
 
 func (bot *Bot) processStandup(member model.Standuper) (string, int) {
 	var text string
 	var points int
 
-	t := time.Now().AddDate(0, 0, -1)
-
-	timeFrom := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local).Unix()
-	timeTo := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.Local).Unix()
-
 	channel, err := bot.db.SelectProject(member.ChannelID)
 	if err != nil {
 		log.Error("reporting SelectProject failed: ", err)
 		return "", points
 	}
 
-	standup, err := bot.db.GetStandupForPeriod(member.UserID, member.ChannelID, timeFrom, timeTo)
+	t := yesterdayTime(channel)
+
+	if bot.onLeave(member.UserID, t.Format("2006-01-02")) {
+		onLeave, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "onLeave",
+				Other: "on leave :palm_tree: ",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return onLeave, points + 1
+	}
+
+	checkIns := checkInProjects(channel)
+	if len(checkIns) == 1 {
+		return bot.processCheckInStandup(member, checkIns[0], t)
+	}
+
+	//standuper gets the point for the standup only if every check-in is answered
+	points = 1
+	for _, checkIn := range checkIns {
+		checkInText, checkInPoints := bot.processCheckInStandup(member, checkIn, t)
+		if checkInPoints == 0 {
+			points = 0
+		}
+		if checkInText == "" {
+			continue
+		}
+		name := checkIn.CheckInName
+		if name == "" {
+			name = checkIn.Deadline
+		}
+		text += "*" + name + "*: " + checkInText
+	}
+	return text, points
+}
+
+//processCheckInStandup describes standup of the member for the check-in on the day of t
+func (bot *Bot) processCheckInStandup(member model.Standuper, channel model.Project, t time.Time) (string, int) {
+	var text string
+	var points int
+
+	standup, err := bot.db.GetStandupForDate(member.UserID, member.ChannelID, t.Format("2006-01-02"), channel.CheckInName)
 	if err != nil {
-		log.Error("GetStandupForPeriod failed: ", err)
+		log.Error("GetStandupForDate failed: ", err)
 		return "", points
 	}
 	if standup == nil {
@@ -572,6 +441,17 @@ func (bot *Bot) processStandup(member model.Standuper) (string, int) {
 			log.Error(err)
 		}
 		text = noStandup
+	} else if standup.Retracted() {
+		retractedStandup, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "retractedStandup",
+				Other: "standup retracted :wastebasket: ",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text = retractedStandup
 	} else {
 		hasStandup, err := bot.localizer.Localize(&i18n.LocalizeConfig{
 			DefaultMessage: &i18n.Message{
@@ -584,6 +464,35 @@ func (bot *Bot) processStandup(member model.Standuper) (string, int) {
 		}
 		text = hasStandup
 		points++
+
+		if standup.Late() {
+			lateStandup, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "lateStandup",
+					Other: "standup {{.Lateness}} :snail: ",
+				},
+				TemplateData: map[string]interface{}{
+					"Lateness": bot.latenessText(*standup),
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			text += lateStandup
+		}
+
+		if standup.Backfilled() {
+			backfilledStandup, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "backfilledStandup",
+					Other: "standup submitted later :hourglass: ",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			text += backfilledStandup
+		}
 	}
 
 	return text, points
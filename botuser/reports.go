@@ -0,0 +1,436 @@
+package botuser
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//ProjectReport is a report on standupers of the project for the date range
+type ProjectReport struct {
+	ChannelID   string             `json:"channel_id"`
+	ChannelName string             `json:"channel_name"`
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Attachments []slack.Attachment `json:"attachments"`
+}
+
+//standupCount sums up standups of a standuper over the date range
+type standupCount struct {
+	Expected  int
+	Submitted int
+	Late      int
+	//LateMinutes sums lateness of late standups
+	LateMinutes int
+	Leave       int
+	Skipped     int
+}
+
+//reportDays is the longest date range a report covers
+const reportDays = 366
+
+//parseReportArgs parses `[channel] [from] [to]` of /report command. Without dates the
+//report covers the last seven days, a single date is a report on that day
+func parseReportArgs(text string, now time.Time) (string, time.Time, time.Time, error) {
+	var channel string
+	dates := []time.Time{}
+	for _, arg := range strings.Fields(text) {
+		date, err := time.Parse("2006-01-02", arg)
+		if err == nil {
+			dates = append(dates, date)
+			continue
+		}
+		if channel != "" || len(dates) > 0 {
+			return "", time.Time{}, time.Time{}, errors.New("wrong report arguments")
+		}
+		channel = reportChannel(arg)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch len(dates) {
+	case 0:
+		return channel, today.AddDate(0, 0, -7), today.AddDate(0, 0, -1), nil
+	case 1:
+		return channel, dates[0], dates[0], nil
+	case 2:
+		from, to, err := reportRange(dates[0], dates[1])
+		return channel, from, to, err
+	}
+	return "", time.Time{}, time.Time{}, errors.New("wrong report arguments")
+}
+
+//reportRange checks that the range is ordered and not too long
+func reportRange(from, to time.Time) (time.Time, time.Time, error) {
+	if from.After(to) {
+		return from, to, errors.New("report start date is after its end date")
+	}
+	if to.Sub(from) >= reportDays*24*time.Hour {
+		return from, to, errors.New("report date range is too long")
+	}
+	return from, to, nil
+}
+
+//reportChannel takes channel ID out of slack channel mention like <#CBAP453GV|general>,
+//channel names lose leading #
+func reportChannel(arg string) string {
+	if strings.HasPrefix(arg, "<#") && strings.HasSuffix(arg, ">") {
+		return strings.SplitN(strings.Trim(arg, "<#>"), "|", 2)[0]
+	}
+	return strings.TrimPrefix(arg, "#")
+}
+
+//countStandups counts submission days of the project in the date range and standups
+//submitted for every check-in on them. Days on leave and skipped days are not expected
+func countStandups(project model.Project, standups []model.Standup, absences []model.Absence, skipped map[string]bool, from, to time.Time) standupCount {
+	count := standupCount{}
+	submitted := map[string]model.Standup{}
+	for _, standup := range standups {
+		if standup.Retracted() {
+			continue
+		}
+		submitted[standup.StandupDate+"/"+standup.CheckIn] = standup
+	}
+
+	checkIns := checkInProjects(project)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !shouldSubmitStandupIn(&project, day) {
+			continue
+		}
+		date := day.Format("2006-01-02")
+		if absentOn(absences, date) {
+			count.Leave++
+			continue
+		}
+		if skipped[date] {
+			count.Skipped++
+			continue
+		}
+		for _, checkIn := range checkIns {
+			count.Expected++
+			standup, ok := submitted[date+"/"+checkIn.CheckInName]
+			if !ok {
+				continue
+			}
+			count.Submitted++
+			if standup.Late() {
+				count.Late++
+				count.LateMinutes += standup.LateMinutes
+			}
+		}
+	}
+	return count
+}
+
+//rangeWorklogsPoints judges time logged over the submission days by weekly thresholds
+//scaled from five days to their number
+func rangeWorklogsPoints(totalWorklogs, days int, thresholds model.Thresholds) (string, int) {
+	if days == 0 {
+		return "", thresholds.MaxPoints()
+	}
+	return thresholds.Judge(totalWorklogs / 3600 * 5 / days)
+}
+
+//TeamReport builds reports on the workspace projects for the date range in YYYY-MM-DD format.
+//Channel is an ID or a name of the project, all projects are reported on when it is empty
+func (bot *Bot) TeamReport(channel, from, to string) ([]ProjectReport, error) {
+	dateFrom, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, errors.New("wrong report start date, use YYYY-MM-DD format")
+	}
+	dateTo, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, errors.New("wrong report end date, use YYYY-MM-DD format")
+	}
+	dateFrom, dateTo, err = reportRange(dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := bot.reportProjects(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []ProjectReport{}
+	for _, project := range projects {
+		attachments := bot.projectRangeReport(project, dateFrom, dateTo)
+		if len(attachments) == 0 {
+			continue
+		}
+		reports = append(reports, ProjectReport{
+			ChannelID:   project.ChannelID,
+			ChannelName: project.ChannelName,
+			From:        dateFrom.Format("2006-01-02"),
+			To:          dateTo.Format("2006-01-02"),
+			Attachments: attachments,
+		})
+	}
+	return reports, nil
+}
+
+//reportProjects returns workspace projects matching channel ID or name, all of them for empty channel
+func (bot *Bot) reportProjects(channel string) ([]model.Project, error) {
+	projects, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if channel == "" {
+		return projects, nil
+	}
+	for _, project := range projects {
+		if project.ChannelID == channel || project.ChannelName == channel {
+			return []model.Project{project}, nil
+		}
+	}
+	return nil, errors.New("channel is not a workspace project")
+}
+
+//projectRangeReport builds sorted attachments on project standupers for the date range
+func (bot *Bot) projectRangeReport(project model.Project, from, to time.Time) []slack.Attachment {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		log.Errorf("ListProjectStandupers failed for channel %v: %v", project.ChannelName, err)
+		return nil
+	}
+
+	skips := bot.projectSkips(project, from, to)
+
+	sections := bot.workspaceReportSections()
+	var attachmentsPull []AttachmentItem
+	for _, standuper := range standupers {
+		entry := &ReportEntry{
+			Standuper: standuper,
+			Project:   project,
+			From:      from,
+			To:        to,
+			Skipped:   skips[standuper.UserID],
+		}
+		fieldValue, points, maxPoints := bot.reportOn(entry, sections)
+		if fieldValue == "" {
+			continue
+		}
+
+		attachment := bot.reportAttachment(standuper, project, points, maxPoints)
+		attachment.Fields = []slack.AttachmentField{{
+			Value: fieldValue,
+			Short: false,
+		}}
+		attachmentsPull = append(attachmentsPull, AttachmentItem{
+			SlackAttachment: attachment,
+			Points:          entry.projectWorklogs(),
+		})
+	}
+
+	if len(attachmentsPull) == 0 {
+		return nil
+	}
+	return bot.sortReportEntries(attachmentsPull)
+}
+
+//projectSkips returns dates standupers of the project skipped in the date range by user
+func (bot *Bot) projectSkips(project model.Project, from, to time.Time) map[string]map[string]bool {
+	skips := map[string]map[string]bool{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		for _, excuse := range bot.projectExcuses(project, date) {
+			if excuse.Kind != model.ExcuseSkip {
+				continue
+			}
+			if skips[excuse.UserID] == nil {
+				skips[excuse.UserID] = map[string]bool{}
+			}
+			skips[excuse.UserID][date] = true
+		}
+	}
+	return skips
+}
+
+//rangeStandupsText shows how many standups are submitted, the point is given when all of them are
+func (bot *Bot) rangeStandupsText(count standupCount) (string, int) {
+	var text string
+	points := 1
+	if count.Expected > 0 {
+		emoji := ":memo:"
+		if count.Submitted < count.Expected {
+			emoji = ":no_entry_sign:"
+			points = 0
+		}
+		submittedStandups, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "submittedStandups",
+				Other: "standups {{.Submitted}}/{{.Expected}} {{.Emoji}} ",
+			},
+			TemplateData: map[string]interface{}{
+				"Submitted": count.Submitted,
+				"Expected":  count.Expected,
+				"Emoji":     emoji,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text += submittedStandups
+	}
+
+	if count.Late > 0 {
+		lateStandups, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "lateStandups",
+				Other: "late {{.Late}} :snail: ",
+			},
+			TemplateData: map[string]interface{}{"Late": count.Late},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text += lateStandups
+	}
+
+	if count.Leave > 0 {
+		onLeaveDays, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "onLeaveDays",
+				One:   "on leave {{.Days}} day :palm_tree: ",
+				Other: "on leave {{.Days}} days :palm_tree: ",
+			},
+			PluralCount:  count.Leave,
+			TemplateData: map[string]interface{}{"Days": count.Leave},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text += onLeaveDays
+	}
+
+	if count.Skipped > 0 {
+		skippedDays, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "skippedDays",
+				One:   "skipped {{.Days}} day :see_no_evil: ",
+				Other: "skipped {{.Days}} days :see_no_evil: ",
+			},
+			PluralCount:  count.Skipped,
+			TemplateData: map[string]interface{}{"Days": count.Skipped},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text += skippedDays
+	}
+
+	return text, points
+}
+
+//reportAttachment colors report entry by points it got out of the most it could get,
+//standupers who lost points are tagged
+func (bot *Bot) reportAttachment(standuper model.Standuper, project model.Project, points, maxPoints int) slack.Attachment {
+	var attachment slack.Attachment
+	message := &i18n.Message{
+		ID:    "notTagStanduper",
+		Other: "",
+	}
+	user := standuper.RealName
+	if points < maxPoints {
+		message = &i18n.Message{
+			ID:    "tagStanduper",
+			Other: "",
+		}
+		user = standuper.UserID
+	}
+
+	text, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: message,
+		TemplateData:   map[string]interface{}{"user": user, "channel": project.ChannelName},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	attachment.Text = text
+
+	switch {
+	case points <= 0:
+		attachment.Color = "danger"
+	case points < maxPoints:
+		attachment.Color = "warning"
+	default:
+		attachment.Color = "good"
+	}
+	return attachment
+}
+
+//reportCommand handles /report command: posts reports on the channel, or on every project,
+//for the date range to the channel the command is called in
+func (bot *Bot) reportCommand(command slack.SlashCommand) string {
+	channel, from, to, err := parseReportArgs(command.Text, time.Now())
+	if err != nil {
+		return bot.wrongReport()
+	}
+
+	reports, err := bot.TeamReport(channel, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		log.Error("TeamReport failed: ", err)
+		return bot.wrongReport()
+	}
+
+	if len(reports) == 0 {
+		noReport, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "noReport",
+				Other: "Nothing to report from {{.From}} to {{.To}}",
+			},
+			TemplateData: map[string]interface{}{
+				"From": from.Format("2006-01-02"),
+				"To":   to.Format("2006-01-02"),
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return noReport
+	}
+
+	for _, report := range reports {
+		rangeReportHeader, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "rangeReportHeader",
+				Other: "Report on #{{.Channel}} from {{.From}} to {{.To}}",
+			},
+			TemplateData: map[string]interface{}{
+				"Channel": report.ChannelName,
+				"From":    report.From,
+				"To":      report.To,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		err = bot.send(&Message{
+			Type:        "message",
+			Channel:     command.ChannelID,
+			Text:        rangeReportHeader,
+			Attachments: report.Attachments,
+		})
+		if err != nil {
+			log.Error("send message failed ", err)
+		}
+	}
+	return ""
+}
+
+func (bot *Bot) wrongReport() string {
+	wrongReport, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "wrongReport",
+			Other: "Use `/report #channel 2019-09-02 2019-09-13` to see a report on the channel for the dates, leave out the channel to see every project and the dates to see the last week",
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return wrongReport
+}
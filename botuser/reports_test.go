@@ -0,0 +1,95 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReportArgs(t *testing.T) {
+	now := time.Date(2019, 9, 16, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		text    string
+		channel string
+		from    string
+		to      string
+		err     bool
+	}{
+		{"", "", "2019-09-09", "2019-09-15", false},
+		{"<#CBAP453GV|general>", "CBAP453GV", "2019-09-09", "2019-09-15", false},
+		{"#backend 2019-09-02 2019-09-13", "backend", "2019-09-02", "2019-09-13", false},
+		{"2019-09-02 2019-09-13", "", "2019-09-02", "2019-09-13", false},
+		{"backend 2019-09-10", "backend", "2019-09-10", "2019-09-10", false},
+		{"2019-09-13 2019-09-02", "", "", "", true},
+		{"2019-09-02 backend", "", "", "", true},
+		{"backend frontend", "", "", "", true},
+		{"2018-01-01 2019-09-13", "", "", "", true},
+		{"2019-09-01 2019-09-02 2019-09-03", "", "", "", true},
+	}
+	for _, tt := range testCases {
+		channel, from, to, err := parseReportArgs(tt.text, now)
+		if tt.err {
+			assert.Error(t, err, tt.text)
+			continue
+		}
+		assert.NoError(t, err, tt.text)
+		assert.Equal(t, tt.channel, channel, tt.text)
+		assert.Equal(t, tt.from, from.Format("2006-01-02"), tt.text)
+		assert.Equal(t, tt.to, to.Format("2006-01-02"), tt.text)
+	}
+}
+
+func TestCountStandups(t *testing.T) {
+	project := model.Project{
+		Deadline:       "10am",
+		TZ:             "UTC",
+		SubmissionDays: "weekdays",
+	}
+	//2019-09-02 is monday
+	from := time.Date(2019, 9, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	standups := []model.Standup{
+		{StandupDate: "2019-09-02"},
+		{StandupDate: "2019-09-03", DeadlineAt: 1, LateMinutes: 15},
+		{StandupDate: "2019-09-04", RetractedAt: 1},
+		{StandupDate: "2019-09-07"},
+		{StandupDate: "2019-09-09"},
+	}
+	absences := []model.Absence{{DateFrom: "2019-09-05", DateTo: "2019-09-05"}}
+	skipped := map[string]bool{"2019-09-06": true}
+
+	count := countStandups(project, standups, absences, skipped, from, to)
+	assert.Equal(t, standupCount{Expected: 3, Submitted: 2, Late: 1, LateMinutes: 15, Leave: 1, Skipped: 1}, count)
+
+	//every check-in of the day is expected
+	project.CheckIns = []model.CheckIn{{Name: "evening", Deadline: "6pm"}}
+	standups = append(standups, model.Standup{StandupDate: "2019-09-02", CheckIn: "evening"})
+	count = countStandups(project, standups, nil, nil, from, to)
+	assert.Equal(t, standupCount{Expected: 10, Submitted: 3, Late: 1, LateMinutes: 15}, count)
+}
+
+func TestRangeWorklogsPoints(t *testing.T) {
+	testCases := []struct {
+		hours  int
+		days   int
+		emoji  string
+		points int
+	}{
+		{0, 0, "", 1},
+		{30, 5, ":disappointed:", 0},
+		{31, 5, ":wink:", 1},
+		{35, 5, ":sunglasses:", 1},
+		{70, 10, ":sunglasses:", 1},
+		{6, 1, ":disappointed:", 0},
+		{7, 1, ":sunglasses:", 1},
+	}
+	for _, tt := range testCases {
+		emoji, points := rangeWorklogsPoints(tt.hours*3600, tt.days, model.DefaultScoring.WeeklyWorklogs)
+		assert.Equal(t, tt.emoji, emoji)
+		assert.Equal(t, tt.points, points)
+	}
+}
@@ -0,0 +1,263 @@
+package botuser
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//rollupPeriod returns the first and the last day of the month or the quarter the day is in
+func rollupPeriod(period string, day time.Time) (time.Time, time.Time, error) {
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	switch period {
+	case model.RollupMonth:
+		return first, first.AddDate(0, 1, -1), nil
+	case model.RollupQuarter:
+		first = first.AddDate(0, -(int(first.Month())-1)%3, 0)
+		return first, first.AddDate(0, 3, -1), nil
+	}
+	return time.Time{}, time.Time{}, errors.New("wrong rollup period, use month or quarter")
+}
+
+//lastRollupDay returns a day of the last finished month or quarter
+func lastRollupDay(period string, now time.Time) (time.Time, error) {
+	from, _, err := rollupPeriod(period, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return from.AddDate(0, 0, -1), nil
+}
+
+//sumUpRollup fills rates and expectations of the rollup from standups counted over the period.
+//Standupers are expected to log the daily worklogs norm on every submission day
+func sumUpRollup(rollup *model.Rollup, count standupCount, checkIns int, rules model.ScoringRules) {
+	rollup.ExpectedStandups = count.Expected
+	rollup.SubmittedStandups = count.Submitted
+	rollup.LateStandups = count.Late
+	if count.Expected > 0 {
+		rollup.SubmissionRate = math.Round(float64(count.Submitted)/float64(count.Expected)*1000) / 10
+	}
+	if count.Late > 0 {
+		rollup.AverageLateMinutes = math.Round(float64(count.LateMinutes)/float64(count.Late)*10) / 10
+	}
+	if checkIns > 0 {
+		rollup.ExpectedWorklogs = count.Expected / checkIns * rules.DailyWorklogs.Expected() * 3600
+	}
+}
+
+//rollupPoints gives a point for submitting every standup and one for logging the expected time
+func rollupPoints(rollup model.Rollup) int {
+	points := 0
+	if rollup.SubmittedStandups >= rollup.ExpectedStandups {
+		points++
+	}
+	if rollup.CollectorError != "" || rollup.Worklogs >= rollup.ExpectedWorklogs {
+		points++
+	}
+	return points
+}
+
+//Rollups sums up standupers of the workspace projects over the month or the quarter the date
+//in YYYY-MM-DD format is in, the last finished period is taken when the date is empty.
+//Channel is an ID or a name of the project, all projects are summed up when it is empty
+func (bot *Bot) Rollups(channel, period, date string) ([]model.Rollup, error) {
+	day, err := lastRollupDay(period, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if date != "" {
+		day, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("wrong rollup date, use YYYY-MM-DD format")
+		}
+	}
+	from, to, err := rollupPeriod(period, day)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := bot.reportProjects(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	rollups := []model.Rollup{}
+	for _, project := range projects {
+		rollups = append(rollups, bot.projectRollups(project, period, from, to)...)
+	}
+	return rollups, nil
+}
+
+//projectRollups sums up every standuper of the project over the period
+func (bot *Bot) projectRollups(project model.Project, period string, from, to time.Time) []model.Rollup {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		log.Errorf("ListProjectStandupers failed for channel %v: %v", project.ChannelName, err)
+		return nil
+	}
+
+	skips := bot.projectSkips(project, from, to)
+
+	var rollups []model.Rollup
+	for _, standuper := range standupers {
+		entry := &ReportEntry{
+			Standuper: standuper,
+			Project:   project,
+			From:      from,
+			To:        to,
+			Skipped:   skips[standuper.UserID],
+		}
+		rollup := model.Rollup{
+			UserID:      standuper.UserID,
+			RealName:    standuper.RealName,
+			ChannelID:   project.ChannelID,
+			ChannelName: project.ChannelName,
+			Period:      period,
+			From:        from.Format("2006-01-02"),
+			To:          to.Format("2006-01-02"),
+		}
+		sumUpRollup(&rollup, entry.standups(bot), len(checkInProjects(project)), entry.scoring(bot))
+
+		dataOnUser, dataOnUserInProject, err := entry.collector(bot)
+		if err != nil {
+			rollup.CollectorError = err.Error()
+		} else {
+			rollup.Worklogs = dataOnUser.Worklogs
+			rollup.ProjectWorklogs = dataOnUserInProject.Worklogs
+			rollup.Commits = dataOnUserInProject.Commits
+		}
+		rollups = append(rollups, rollup)
+	}
+	return rollups
+}
+
+//displayRollupReport posts rollups of the month or the quarter the day is in
+//on every workspace project to its reporting channel
+func (bot *Bot) displayRollupReport(period string, day time.Time) (string, error) {
+	from, to, err := rollupPeriod(period, day)
+	if err != nil {
+		return "", err
+	}
+
+	channels, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	destinations := reportDestinations{}
+	var allReports []slack.Attachment
+	for _, project := range channels {
+		var attachmentsPull []AttachmentItem
+		for _, rollup := range bot.projectRollups(project, period, from, to) {
+			if rollup.ExpectedStandups == 0 && rollup.Worklogs == 0 && rollup.Commits == 0 {
+				continue
+			}
+			attachmentsPull = append(attachmentsPull, AttachmentItem{
+				SlackAttachment: bot.rollupAttachment(rollup),
+				Points:          rollup.ProjectWorklogs,
+			})
+		}
+		if len(attachmentsPull) == 0 {
+			continue
+		}
+		attachments := bot.sortReportEntries(attachmentsPull)
+		allReports = append(allReports, attachments...)
+		destinations.add(bot.reportingChannelID(project, channels), attachments)
+	}
+
+	if len(allReports) == 0 {
+		return "", nil
+	}
+
+	message := &i18n.Message{
+		ID:    "monthlyReportHeader",
+		Other: "Monthly report from {{.From}} to {{.To}}",
+	}
+	if period == model.RollupQuarter {
+		message = &i18n.Message{
+			ID:    "quarterlyReportHeader",
+			Other: "Quarterly report from {{.From}} to {{.To}}",
+		}
+	}
+	header, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: message,
+		TemplateData: map[string]interface{}{
+			"From": from.Format("2006-01-02"),
+			"To":   to.Format("2006-01-02"),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	return header, bot.sendReports(destinations, header)
+}
+
+//rollupAttachment renders the rollup, standupers who missed standups or logged
+//less than expected are tagged
+func (bot *Bot) rollupAttachment(rollup model.Rollup) slack.Attachment {
+	standuper := model.Standuper{UserID: rollup.UserID, RealName: rollup.RealName}
+	project := model.Project{ChannelID: rollup.ChannelID, ChannelName: rollup.ChannelName}
+	attachment := bot.reportAttachment(standuper, project, rollupPoints(rollup), 2)
+
+	rollupStandups, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "rollupStandups",
+			Other: "standups {{.Submitted}}/{{.Expected}} ({{.Rate}}%) :memo: ",
+		},
+		TemplateData: map[string]interface{}{
+			"Submitted": rollup.SubmittedStandups,
+			"Expected":  rollup.ExpectedStandups,
+			"Rate":      rollup.SubmissionRate,
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	text := rollupStandups
+
+	if rollup.LateStandups > 0 {
+		rollupLateness, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "rollupLateness",
+				Other: "late {{.Late}}, {{.Minutes}} min on average :snail: ",
+			},
+			TemplateData: map[string]interface{}{
+				"Late":    rollup.LateStandups,
+				"Minutes": rollup.AverageLateMinutes,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text += rollupLateness
+	}
+
+	if rollup.CollectorError == "" {
+		rollupWorklogs, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "rollupWorklogs",
+				Other: "worklogs {{.Worklogs}} of {{.Expected}} expected :clock1: ",
+			},
+			TemplateData: map[string]interface{}{
+				"Worklogs": SecondsToHuman(rollup.Worklogs),
+				"Expected": SecondsToHuman(rollup.ExpectedWorklogs),
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		text += rollupWorklogs + bot.commitsText(rollup.Commits, "")
+	}
+
+	attachment.Fields = []slack.AttachmentField{{
+		Value: text,
+		Short: false,
+	}}
+	return attachment
+}
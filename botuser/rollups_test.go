@@ -0,0 +1,64 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollupPeriod(t *testing.T) {
+	testCases := []struct {
+		period string
+		day    time.Time
+		from   string
+		to     string
+		err    bool
+	}{
+		{model.RollupMonth, time.Date(2019, 2, 14, 10, 0, 0, 0, time.UTC), "2019-02-01", "2019-02-28", false},
+		{model.RollupMonth, time.Date(2019, 12, 31, 23, 0, 0, 0, time.UTC), "2019-12-01", "2019-12-31", false},
+		{model.RollupQuarter, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), "2019-01-01", "2019-03-31", false},
+		{model.RollupQuarter, time.Date(2019, 5, 20, 0, 0, 0, 0, time.UTC), "2019-04-01", "2019-06-30", false},
+		{model.RollupQuarter, time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC), "2019-10-01", "2019-12-31", false},
+		{"year", time.Date(2019, 5, 20, 0, 0, 0, 0, time.UTC), "", "", true},
+	}
+	for _, tt := range testCases {
+		from, to, err := rollupPeriod(tt.period, tt.day)
+		if tt.err {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.from, from.Format("2006-01-02"))
+		assert.Equal(t, tt.to, to.Format("2006-01-02"))
+	}
+
+	day, err := lastRollupDay(model.RollupMonth, time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-02-28", day.Format("2006-01-02"))
+	day, err = lastRollupDay(model.RollupQuarter, time.Date(2019, 5, 20, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-03-31", day.Format("2006-01-02"))
+}
+
+func TestSumUpRollup(t *testing.T) {
+	rollup := model.Rollup{}
+	count := standupCount{Expected: 20, Submitted: 15, Late: 4, LateMinutes: 50}
+	sumUpRollup(&rollup, count, 2, model.DefaultScoring)
+	assert.Equal(t, 75.0, rollup.SubmissionRate)
+	assert.Equal(t, 12.5, rollup.AverageLateMinutes)
+	assert.Equal(t, 10*7*3600, rollup.ExpectedWorklogs)
+
+	rollup.Worklogs = 10 * 7 * 3600
+	assert.Equal(t, 1, rollupPoints(rollup))
+	rollup.SubmittedStandups = 20
+	assert.Equal(t, 2, rollupPoints(rollup))
+
+	rollup = model.Rollup{}
+	sumUpRollup(&rollup, standupCount{}, 1, model.DefaultScoring)
+	assert.Equal(t, 0.0, rollup.SubmissionRate)
+	assert.Equal(t, 0.0, rollup.AverageLateMinutes)
+	assert.Equal(t, 0, rollup.ExpectedWorklogs)
+	assert.Equal(t, 2, rollupPoints(rollup))
+}
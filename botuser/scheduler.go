@@ -0,0 +1,375 @@
+package botuser
+
+import (
+	"errors"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	log "github.com/sirupsen/logrus"
+)
+
+//jobHorizon is how far ahead jobs are planned
+const jobHorizon = 24 * time.Hour
+
+//jobHistory is how long finished jobs are kept
+const jobHistory = 7 * 24 * time.Hour
+
+//plannedJobKinds are kinds of jobs planned from project and workspace settings,
+//pending jobs of these kinds are dropped when settings no longer need them
+var plannedJobKinds = map[string]bool{
+	model.JobHeadsUp:          true,
+	model.JobEscalation:       true,
+	model.JobDMStandup:        true,
+	model.JobDailyReport:      true,
+	model.JobWeeklyReport:     true,
+	model.JobWorklogsReminder: true,
+	model.JobMonthlyReport:    true,
+	model.JobQuarterlyReport:  true,
+}
+
+//atTimeOfDay returns time of day like 10am or 10:00 on the day given in its location
+func atTimeOfDay(text string, day time.Time) (time.Time, bool) {
+	hour, minute, err := model.ParseTimeOfDay(text)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location()), true
+}
+
+//projectTimes returns moments within [from, to) that are offset minutes away from time
+//of day given on submission days of the project, in the project timezone
+func projectTimes(project model.Project, timeOfDay string, offsets []int64, from, to time.Time) []time.Time {
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		return nil
+	}
+
+	//offsets may move moments to adjacent days
+	start := from.In(loc).AddDate(0, 0, -1)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 12, 0, 0, 0, loc)
+	times := []time.Time{}
+	for ; day.Before(to.AddDate(0, 0, 1)); day = day.AddDate(0, 0, 1) {
+		if !shouldSubmitStandupIn(&project, day) {
+			continue
+		}
+		at, ok := atTimeOfDay(timeOfDay, day)
+		if !ok {
+			continue
+		}
+		for _, offset := range offsets {
+			t := at.Add(time.Duration(offset) * time.Minute)
+			if !t.Before(from) && t.Before(to) {
+				times = append(times, t)
+			}
+		}
+	}
+	return times
+}
+
+//workspaceTimes returns moments within [from, to) at time of day given in server
+//timezone on the days that pass the filter
+func workspaceTimes(timeOfDay string, from, to time.Time, filter func(day time.Time) bool) []time.Time {
+	return zoneTimes(timeOfDay, time.Local, from, to, filter)
+}
+
+//zoneTimes returns moments within [from, to) at time of day given in the location
+//on the days that pass the filter
+func zoneTimes(timeOfDay string, loc *time.Location, from, to time.Time, filter func(day time.Time) bool) []time.Time {
+	start := from.In(loc)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 12, 0, 0, 0, loc)
+	times := []time.Time{}
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if filter != nil && !filter(day) {
+			continue
+		}
+		at, ok := atTimeOfDay(timeOfDay, day)
+		if ok && !at.Before(from) && at.Before(to) {
+			times = append(times, at)
+		}
+	}
+	return times
+}
+
+//graceWindow is how late a job may start, jobs that could not start in time are missed
+func (bot *Bot) graceWindow() time.Duration {
+	return time.Duration(bot.conf.JobGraceMinutes) * time.Minute
+}
+
+//plannedJobs returns jobs the workspace and its projects need within [from, to)
+func (bot *Bot) plannedJobs(from, to time.Time) ([]model.Job, error) {
+	projects, err := bot.db.ListWorkspaceProjects(bot.workspace.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []model.Job{}
+	add := func(kind, channelID string, times []time.Time) {
+		for _, t := range times {
+			jobs = append(jobs, model.Job{
+				WorkspaceID: bot.workspace.WorkspaceID,
+				ChannelID:   channelID,
+				Kind:        kind,
+				RunAt:       t.Unix(),
+				Status:      model.JobPending,
+			})
+		}
+	}
+
+	for _, project := range projects {
+		for _, kind := range []string{model.JobDailyReport, model.JobWeeklyReport} {
+			if reportsEnabled(project, kind) && ownReportSchedule(project, kind) {
+				add(kind, project.ChannelID, bot.projectReportTimes(project, kind, from, to))
+			}
+		}
+
+		if project.DMStandupTime != "" {
+			add(model.JobDMStandup, project.ChannelID, projectTimes(project, project.DMStandupTime, []int64{0}, from, to))
+		}
+		if project.Deadline == "" && len(project.CheckIns) == 0 {
+			continue
+		}
+
+		headsUps, escalations, err := bot.deadlineOffsets(project)
+		if err != nil {
+			log.Errorf("could not plan reminders of %v: %v", project.ChannelName, err)
+			continue
+		}
+		for _, checkIn := range checkInProjects(project) {
+			for tz, offsets := range headsUps {
+				local := checkIn
+				local.TZ = tz
+				add(model.JobHeadsUp, project.ChannelID, projectTimes(local, local.Deadline, offsets, from, to))
+			}
+			for _, tz := range escalations {
+				local := checkIn
+				local.TZ = tz
+				add(model.JobEscalation, project.ChannelID, projectTimes(local, local.Deadline, bot.escalationOffsets(project), from, to))
+			}
+		}
+	}
+
+	if bot.workspace.ReportingTime != "" {
+		add(model.JobDailyReport, "", workspaceTimes(bot.workspace.ReportingTime, from, to, nil))
+		add(model.JobWeeklyReport, "", workspaceTimes(bot.workspace.ReportingTime, from, to, func(day time.Time) bool {
+			return day.Weekday() == time.Sunday
+		}))
+		add(model.JobMonthlyReport, "", workspaceTimes(bot.workspace.ReportingTime, from, to, func(day time.Time) bool {
+			return day.Day() == 1
+		}))
+		add(model.JobQuarterlyReport, "", workspaceTimes(bot.workspace.ReportingTime, from, to, func(day time.Time) bool {
+			return day.Day() == 1 && (day.Month()-1)%3 == 0
+		}))
+	}
+	add(model.JobWorklogsReminder, "", workspaceTimes("10:00", from, to, func(day time.Time) bool {
+		return day.AddDate(0, 0, 1).Day() == 1
+	}))
+
+	return jobs, nil
+}
+
+//deadlineOffsets returns heads up offsets from the deadline by timezone and timezones
+//escalation runs in. In user deadline mode every standuper timezone has its own runs
+func (bot *Bot) deadlineOffsets(project model.Project) (map[string][]int64, []string, error) {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headsUps := map[string][]int64{}
+	known := map[string]map[int64]bool{}
+	for _, standuper := range standupers {
+		tz := bot.standuperProject(project, standuper.UserID).TZ
+		if known[tz] == nil {
+			known[tz] = map[int64]bool{}
+		}
+
+		offset := -bot.headsUpMinutes(bot.notificationPreference(standuper.UserID))
+		if !known[tz][offset] {
+			headsUps[tz] = append(headsUps[tz], offset)
+		}
+		known[tz][offset] = true
+	}
+
+	zones := []string{project.TZ}
+	if project.DeadlineMode == model.DeadlineUser {
+		zones = []string{}
+		for tz := range known {
+			zones = append(zones, tz)
+		}
+	}
+	return headsUps, zones, nil
+}
+
+//escalationOffsets returns offsets of the project escalation steps from the deadline
+func (bot *Bot) escalationOffsets(project model.Project) []int64 {
+	offsets := []int64{}
+	seen := map[int]bool{}
+	for _, step := range bot.ProjectEscalationPolicy(project) {
+		if !seen[step.OffsetMinutes] {
+			offsets = append(offsets, int64(step.OffsetMinutes))
+		}
+		seen[step.OffsetMinutes] = true
+	}
+	return offsets
+}
+
+//planJobs stores jobs needed in the next jobHorizon and the ones of the grace window
+//that have not been stored yet, pending jobs that are no longer needed are deleted
+func (bot *Bot) planJobs(now time.Time) error {
+	from := now.Add(-bot.graceWindow()).Truncate(time.Minute)
+
+	planned, err := bot.plannedJobs(from, now.Add(jobHorizon))
+	if err != nil {
+		return err
+	}
+
+	stored, err := bot.db.ListJobsSince(bot.workspace.WorkspaceID, from.Unix())
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, job := range stored {
+		existing[job.Key()] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, job := range planned {
+		wanted[job.Key()] = true
+		if existing[job.Key()] {
+			continue
+		}
+		job.CreatedAt = now.Unix()
+		_, err := bot.db.CreateJob(job)
+		if err != nil {
+			log.Errorf("CreateJob %v failed: %v", job.Key(), err)
+		}
+	}
+
+	for _, job := range stored {
+		if job.Status != model.JobPending || !plannedJobKinds[job.Kind] || job.RunAt <= now.Unix() || wanted[job.Key()] {
+			continue
+		}
+		err := bot.db.DeleteJob(job.ID)
+		if err != nil {
+			log.Errorf("DeleteJob %v failed: %v", job.Key(), err)
+		}
+	}
+
+	return bot.db.DeleteFinishedJobs(bot.workspace.WorkspaceID, now.Add(-jobHistory).Unix())
+}
+
+//runDueJobs runs pending jobs which time has come. Job is claimed first, so it runs
+//once even if several bot instances share the database. Jobs later than the grace window are missed
+func (bot *Bot) runDueJobs(now time.Time) error {
+	jobs, err := bot.db.ListDueJobs(bot.workspace.WorkspaceID, now.Unix())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		claimed, err := bot.db.ClaimJob(job.ID, time.Now().Unix())
+		if err != nil {
+			log.Errorf("ClaimJob %v failed: %v", job.Key(), err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		job.Status = model.JobDone
+		if now.Sub(time.Unix(job.RunAt, 0)) > bot.graceWindow() {
+			job.Status = model.JobMissed
+		} else if err := bot.runJob(job); err != nil {
+			log.Errorf("job %v failed: %v", job.Key(), err)
+			job.Status = model.JobFailed
+			job.Error = err.Error()
+		}
+
+		job.FinishedAt = time.Now().Unix()
+		_, err = bot.db.FinishJob(job)
+		if err != nil {
+			log.Errorf("FinishJob %v failed: %v", job.Key(), err)
+		}
+	}
+
+	return nil
+}
+
+//runJob does the job as if it was the time job is planned at
+func (bot *Bot) runJob(job model.Job) error {
+	at := time.Unix(job.RunAt, 0)
+
+	switch job.Kind {
+	case model.JobDailyReport:
+		projects, err := bot.reportedProjects(job)
+		if err != nil {
+			return err
+		}
+		_, err = bot.displayYesterdayTeamReport(projects)
+		return err
+	case model.JobWeeklyReport:
+		projects, err := bot.reportedProjects(job)
+		if err != nil {
+			return err
+		}
+		_, err = bot.displayWeeklyTeamReport(projects)
+		return err
+	case model.JobWorklogsReminder:
+		return bot.remindAboutWorklogs()
+	case model.JobMonthlyReport:
+		//reports run on the first day of the period after the one they sum up
+		_, err := bot.displayRollupReport(model.RollupMonth, at.AddDate(0, 0, -1))
+		return err
+	case model.JobQuarterlyReport:
+		_, err := bot.displayRollupReport(model.RollupQuarter, at.AddDate(0, 0, -1))
+		return err
+	}
+
+	project, err := bot.db.SelectProject(job.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	//every check-in whose time has come reminds about itself
+	switch job.Kind {
+	case model.JobHeadsUp:
+		for _, checkIn := range checkInProjects(project) {
+			err := bot.headsUpNonReporters(checkIn, at)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case model.JobEscalation:
+		for _, checkIn := range checkInProjects(project) {
+			err := bot.escalateNonReporters(checkIn, at)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case model.JobSnoozeOver:
+		bot.remindSnoozed(project, at)
+		return nil
+	case model.JobDMStandup:
+		return bot.startProjectDMStandups(project)
+	}
+
+	return errors.New("unknown job kind " + job.Kind)
+}
+
+//runScheduler plans upcoming jobs and runs the due ones
+func (bot *Bot) runScheduler() {
+	now := time.Now()
+
+	err := bot.planJobs(now)
+	if err != nil {
+		log.Error("planJobs failed: ", err)
+	}
+
+	err = bot.runDueJobs(now)
+	if err != nil {
+		log.Error("runDueJobs failed: ", err)
+	}
+}
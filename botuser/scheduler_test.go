@@ -0,0 +1,91 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectTimes(t *testing.T) {
+	project := model.Project{
+		TZ:             "Asia/Bishkek",
+		Deadline:       "10am",
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+		Holidays:       []model.Holiday{{Date: "2019-09-03", Name: "Holiday"}},
+	}
+
+	//2019-09-02 is monday, 10am in Bishkek is 4am UTC
+	from := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(72 * time.Hour)
+	times := projectTimes(project, project.Deadline, []int64{-15, 0, 30}, from, to)
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 9, 2, 3, 45, 0, 0, time.UTC),
+		time.Date(2019, 9, 2, 4, 0, 0, 0, time.UTC),
+		time.Date(2019, 9, 2, 4, 30, 0, 0, time.UTC),
+		time.Date(2019, 9, 4, 3, 45, 0, 0, time.UTC),
+		time.Date(2019, 9, 4, 4, 0, 0, 0, time.UTC),
+		time.Date(2019, 9, 4, 4, 30, 0, 0, time.UTC),
+	}, utcTimes(times))
+
+	//the window bounds are respected
+	times = projectTimes(project, project.Deadline, []int64{-15, 0, 30}, time.Date(2019, 9, 2, 4, 0, 0, 0, time.UTC), time.Date(2019, 9, 2, 4, 30, 0, 0, time.UTC))
+	assert.Equal(t, []time.Time{time.Date(2019, 9, 2, 4, 0, 0, 0, time.UTC)}, utcTimes(times))
+
+	project.TZ = "Mars/Olympus"
+	assert.Equal(t, 0, len(projectTimes(project, project.Deadline, []int64{0}, from, to)))
+}
+
+func TestWorkspaceTimes(t *testing.T) {
+	from := time.Date(2019, 9, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 14)
+
+	assert.Equal(t, 14, len(workspaceTimes("10am", from, to, nil)))
+
+	sundays := workspaceTimes("10am", from, to, func(day time.Time) bool {
+		return day.Weekday() == time.Sunday
+	})
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 9, 1, 10, 0, 0, 0, time.Local),
+		time.Date(2019, 9, 8, 10, 0, 0, 0, time.Local),
+	}, sundays)
+
+	assert.Equal(t, 0, len(workspaceTimes("", from, to, nil)))
+}
+
+func utcTimes(times []time.Time) []time.Time {
+	utc := []time.Time{}
+	for _, t := range times {
+		utc = append(utc, t.UTC())
+	}
+	return utc
+}
+
+func TestProjectReportTimes(t *testing.T) {
+	bot := &Bot{workspace: &model.Workspace{ReportingTime: "9:00"}}
+	project := model.Project{TZ: "Asia/Bishkek"}
+
+	//2019-09-02 is monday, 9am in Bishkek is 3am UTC
+	from := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+
+	daily := bot.projectReportTimes(project, model.JobDailyReport, from, to)
+	assert.Equal(t, 14, len(daily))
+	assert.Equal(t, time.Date(2019, 9, 2, 3, 0, 0, 0, time.UTC), daily[0].UTC())
+
+	//weekly reports are due on sundays unless the project has its own day
+	weekly := bot.projectReportTimes(project, model.JobWeeklyReport, from, to)
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 9, 8, 3, 0, 0, 0, time.UTC),
+		time.Date(2019, 9, 15, 3, 0, 0, 0, time.UTC),
+	}, utcTimes(weekly))
+
+	project.ReportingTime = "6pm"
+	project.WeeklyReportDay = "friday"
+	weekly = bot.projectReportTimes(project, model.JobWeeklyReport, from, to)
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 9, 6, 12, 0, 0, 0, time.UTC),
+		time.Date(2019, 9, 13, 12, 0, 0, 0, time.UTC),
+	}, utcTimes(weekly))
+}
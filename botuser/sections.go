@@ -0,0 +1,193 @@
+package botuser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/maddevsio/comedian/model"
+	log "github.com/sirupsen/logrus"
+)
+
+//keyword is either a plain word or a regular expression written as /expr/,
+//both are matched ignoring case. Plain words cover the rest of the word they start
+type keyword struct {
+	text string
+	re   *regexp.Regexp
+	word bool
+}
+
+//sectionRule describes how to recognize a standup section
+type sectionRule struct {
+	name     string
+	keywords []keyword
+	required bool
+}
+
+type standupSection struct {
+	name  string
+	start int
+	body  int
+}
+
+//standupRules returns project rules for done, planned and blockers sections.
+//Sections with no keywords configured fall back to the default ones
+func standupRules(project model.Project) []sectionRule {
+	defaults := map[string][]string{
+		"done":     yesterdayWorkKeys,
+		"planned":  todayPlansKeys,
+		"blockers": problemKeys,
+	}
+	configured := map[string]string{
+		"done":     project.DoneKeys,
+		"planned":  project.PlannedKeys,
+		"blockers": project.BlockersKeys,
+	}
+	optional := model.SplitList(project.OptionalSections)
+
+	rules := []sectionRule{}
+	for _, name := range model.StandupSections {
+		keys := model.SplitList(configured[name])
+		if len(keys) == 0 {
+			keys = defaults[name]
+		}
+
+		rule := sectionRule{name: name, required: true}
+		for _, key := range keys {
+			k, err := newKeyword(key)
+			if err != nil {
+				log.Warning(err)
+				continue
+			}
+			rule.keywords = append(rule.keywords, k)
+		}
+		for _, section := range optional {
+			if section == name {
+				rule.required = false
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+//newKeyword compiles the keyword the way project validation does,
+//regular expressions that do not compile are not used as plain words
+func newKeyword(key string) (keyword, error) {
+	re, err := model.CompileKeyword(key)
+	if err != nil {
+		return keyword{}, err
+	}
+	if re != nil {
+		return keyword{text: key, re: re}, nil
+	}
+	return keyword{
+		text: strings.ToLower(key),
+		re:   regexp.MustCompile("(?i)" + regexp.QuoteMeta(key)),
+		word: true,
+	}, nil
+}
+
+//index returns position of the first keyword occurrence and position right after it
+func (k keyword) index(text string) (int, int) {
+	loc := k.re.FindStringIndex(text)
+	if loc == nil {
+		return -1, -1
+	}
+	if k.word {
+		return loc[0], wordEnd(text, loc[1])
+	}
+	return loc[0], loc[1]
+}
+
+func (rule sectionRule) keys() string {
+	keys := []string{}
+	for _, k := range rule.keywords {
+		keys = append(keys, k.text)
+	}
+	return strings.Join(keys, ", ")
+}
+
+//fillSections parses standup comment and fills its done, planned and blockers sections
+func (bot *Bot) fillSections(standup *model.Standup, project model.Project) {
+	text := strings.Replace(standup.Comment, "<@"+bot.workspace.BotUserID+">", "", -1)
+	standup.Done, standup.Planned, standup.Blockers = parseStandup(text, standupRules(project))
+}
+
+//parseStandup splits standup text into done, planned and blockers sections.
+//Each section begins with the first keyword of its kind and lasts until the
+//next section begins. Keywords found at the beginning of a line are preferred
+//over the ones found in the middle of a sentence
+func parseStandup(message string, rules []sectionRule) (done, planned, blockers string) {
+	sections := []standupSection{}
+	for _, rule := range rules {
+		start, body := findSection(message, rule.keywords)
+		if start < 0 {
+			continue
+		}
+		sections = append(sections, standupSection{rule.name, start, body})
+	}
+
+	sort.Slice(sections, func(i, j int) bool {
+		return sections[i].start < sections[j].start
+	})
+
+	texts := map[string]string{}
+	for i, section := range sections {
+		end := len(message)
+		if i+1 < len(sections) {
+			end = sections[i+1].start
+		}
+		if section.body > end {
+			section.body = end
+		}
+		texts[section.name] = cleanSection(message[section.body:end])
+	}
+
+	return texts["done"], texts["planned"], texts["blockers"]
+}
+
+//findSection returns position where section starts and position where its
+//content starts (right after the keyword), or -1 if there is no such section
+func findSection(text string, keywords []keyword) (int, int) {
+	lineStart := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimLeft(line, " \t*_-•#>:")
+		offset := lineStart + len(line) - len(trimmed)
+		for _, k := range keywords {
+			i, end := k.index(trimmed)
+			if i == 0 {
+				return lineStart, offset + end
+			}
+		}
+		lineStart += len(line)
+	}
+
+	start, body := -1, -1
+	for _, k := range keywords {
+		i, end := k.index(text)
+		if i >= 0 && (start < 0 || i < start) {
+			start, body = i, end
+		}
+	}
+	return start, body
+}
+
+//wordEnd skips the rest of the word so that keywords like "пятниц" cover "пятницу"
+func wordEnd(text string, i int) int {
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !unicode.IsLetter(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+func cleanSection(text string) string {
+	text = strings.TrimLeft(text, " \t\n*_:-–—,.")
+	return strings.TrimRight(text, " \t\n*_,")
+}
@@ -0,0 +1,72 @@
+package botuser
+
+import (
+	"testing"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStandup(t *testing.T) {
+	testCases := []struct {
+		message  string
+		done     string
+		planned  string
+		blockers string
+	}{
+		{"wrong standup", "", "", ""},
+		{"Yesterday: fixed login\nToday: reports\nIssues: none", "fixed login", "reports", "none"},
+		{"*Today* reports\n*Yesterday* fixed login\n*Issues* none", "fixed login", "reports", "none"},
+		{"yesterday fixed bug, today write tests, issues: no", "fixed bug", "write tests", "no"},
+		{"Yesterday: closed the ticket planned for today\nToday: deploy\nissue: none", "closed the ticket planned for today", "deploy", "none"},
+		{"В пятницу: сделал отчет\nСегодня: тесты\nМешает: ничего", "сделал отчет", "тесты", "ничего"},
+		{"Yesterday:\n- task 1\n- task 2\nToday:\n- task 3\nissues: -", "task 1\n- task 2", "task 3", ""},
+		{"ВЧЕРА: Поездка в İstanbul\nСЕГОДНЯ: Отчёт для ÖBB\nМЕШАЕТ: Ничего", "Поездка в İstanbul", "Отчёт для ÖBB", "Ничего"},
+		{"YESTERDAY: İzmir RELEASE\nTODAY: Ärger fixen\nISSUES: None", "İzmir RELEASE", "Ärger fixen", "None"},
+	}
+	for _, tt := range testCases {
+		done, planned, blockers := parseStandup(tt.message, standupRules(model.Project{}))
+		assert.Equal(t, tt.done, done, tt.message)
+		assert.Equal(t, tt.planned, planned, tt.message)
+		assert.Equal(t, tt.blockers, blockers, tt.message)
+	}
+}
+
+func TestParseStandupWithProjectRules(t *testing.T) {
+	project := model.Project{
+		DoneKeys:     "gestern, /^erledigt/",
+		PlannedKeys:  "heute",
+		BlockersKeys: "/probleme?/",
+	}
+
+	done, planned, blockers := parseStandup("Erledigt: Login repariert\nHeute: Berichte\nProbleme: keine", standupRules(project))
+	assert.Equal(t, "Login repariert", done)
+	assert.Equal(t, "Berichte", planned)
+	assert.Equal(t, "keine", blockers)
+
+	done, planned, blockers = parseStandup("Yesterday: fixed login\nToday: reports", standupRules(project))
+	assert.Equal(t, "", done)
+	assert.Equal(t, "", planned)
+	assert.Equal(t, "", blockers)
+}
+
+func TestStandupRules(t *testing.T) {
+	rules := standupRules(model.Project{})
+	assert.Equal(t, 3, len(rules))
+	for _, rule := range rules {
+		assert.True(t, rule.required)
+	}
+	assert.Equal(t, "yesterday, friday, вчера, пятниц", rules[0].keys())
+
+	rules = standupRules(model.Project{
+		PlannedKeys:      "hoy, /plan(es)?/",
+		OptionalSections: "blockers",
+	})
+	assert.Equal(t, "hoy, /plan(es)?/", rules[1].keys())
+	assert.True(t, rules[1].required)
+	assert.False(t, rules[2].required)
+
+	//broken regular expression is not matched as plain text
+	rules = standupRules(model.Project{DoneKeys: "/erledigt(/, gestern"})
+	assert.Equal(t, "gestern", rules[0].keys())
+}
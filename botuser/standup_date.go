@@ -0,0 +1,34 @@
+package botuser
+
+import (
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+)
+
+//standupDate returns the date standup submitted at t counts for in YYYY-MM-DD format.
+//The date is taken in project timezone, standups submitted on days off count for
+//the next submission day
+func standupDate(project model.Project, t time.Time) string {
+	loc, err := time.LoadLocation(project.TZ)
+	if err != nil {
+		loc = time.UTC
+	}
+	day := t.In(loc)
+
+	if project.SubmissionDays != "" {
+		for i := 0; i < 366 && !shouldSubmitStandupIn(&project, day); i++ {
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+	return day.Format("2006-01-02")
+}
+
+//fillStandupDate sets standup date from the time standup was created.
+//Backfilled standups keep the date they were submitted for
+func fillStandupDate(standup *model.Standup, project model.Project) {
+	if standup.Backfilled() && standup.StandupDate != "" {
+		return
+	}
+	standup.StandupDate = standupDate(project, time.Unix(standup.CreatedAt, 0))
+}
@@ -0,0 +1,53 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandupDate(t *testing.T) {
+	project := model.Project{
+		TZ:             "Asia/Bishkek",
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+	}
+
+	//2019-09-02 is monday, Asia/Bishkek is UTC+6
+	testCases := []struct {
+		project model.Project
+		t       time.Time
+		date    string
+	}{
+		{project, time.Date(2019, 9, 2, 10, 0, 0, 0, time.UTC), "2019-09-02"},
+		{project, time.Date(2019, 9, 2, 20, 0, 0, 0, time.UTC), "2019-09-03"},
+		{project, time.Date(2019, 9, 1, 19, 0, 0, 0, time.UTC), "2019-09-02"},
+		{project, time.Date(2019, 9, 6, 19, 0, 0, 0, time.UTC), "2019-09-09"},
+		{project, time.Date(2019, 9, 7, 10, 0, 0, 0, time.UTC), "2019-09-09"},
+		{model.Project{TZ: "Asia/Bishkek"}, time.Date(2019, 9, 7, 10, 0, 0, 0, time.UTC), "2019-09-07"},
+		{model.Project{TZ: "Wrong/Zone"}, time.Date(2019, 9, 2, 23, 0, 0, 0, time.UTC), "2019-09-02"},
+	}
+	for _, tt := range testCases {
+		assert.Equal(t, tt.date, standupDate(tt.project, tt.t))
+	}
+}
+
+func TestFillStandupDate(t *testing.T) {
+	project := model.Project{
+		TZ:             "Asia/Bishkek",
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+	}
+
+	standup := model.Standup{CreatedAt: time.Date(2019, 9, 2, 20, 0, 0, 0, time.UTC).Unix()}
+	fillStandupDate(&standup, project)
+	assert.Equal(t, "2019-09-03", standup.StandupDate)
+
+	standup = model.Standup{
+		CreatedAt:    time.Date(2019, 9, 2, 20, 0, 0, 0, time.UTC).Unix(),
+		BackfilledAt: time.Date(2019, 9, 4, 10, 0, 0, 0, time.UTC).Unix(),
+		StandupDate:  "2019-09-02",
+	}
+	fillStandupDate(&standup, project)
+	assert.Equal(t, "2019-09-02", standup.StandupDate)
+}
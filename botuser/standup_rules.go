@@ -0,0 +1,145 @@
+package botuser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//modifyStandupRules updates keywords and required sections of the channel standups.
+//Usage: "/standup_rules <section> <keywords>", "/standup_rules <section> required|optional"
+//or "/standup_rules <section>" to restore default keywords. With no text it shows current rules
+func (bot *Bot) modifyStandupRules(command slack.SlashCommand) string {
+	channel, err := bot.db.SelectProject(command.ChannelID)
+	if err != nil {
+		standupRulesNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "standupRulesNotSet",
+				Other: "Could not change channel standup rules",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return standupRulesNotSet
+	}
+
+	text := strings.TrimSpace(command.Text)
+	if text == "" {
+		return bot.showStandupRules(channel)
+	}
+
+	args := strings.SplitN(text, " ", 2)
+	section := strings.ToLower(args[0])
+	value := ""
+	if len(args) > 1 {
+		value = strings.TrimSpace(args[1])
+	}
+
+	known := false
+	for _, name := range model.StandupSections {
+		if name == section {
+			known = true
+		}
+	}
+	if !known {
+		wrongStandupRules, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "wrongStandupRules",
+				Other: "Unknown section, use one of: done, planned, blockers",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return wrongStandupRules
+	}
+
+	switch value {
+	case "required", "optional":
+		optional := []string{}
+		for _, s := range model.SplitList(channel.OptionalSections) {
+			if s != section {
+				optional = append(optional, s)
+			}
+		}
+		if value == "optional" {
+			optional = append(optional, section)
+		}
+		channel.OptionalSections = strings.Join(optional, ", ")
+	default:
+		switch section {
+		case "done":
+			channel.DoneKeys = value
+		case "planned":
+			channel.PlannedKeys = value
+		case "blockers":
+			channel.BlockersKeys = value
+		}
+	}
+
+	_, err = bot.db.UpdateProject(channel)
+	if err != nil {
+		log.Error(err)
+		msg, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "failedUpdateStandupRules",
+				Other: "Failed to update standup rules: {{.Error}}",
+			},
+			TemplateData: map[string]interface{}{
+				"Error": err,
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return msg
+	}
+
+	return bot.showStandupRules(channel)
+}
+
+func (bot *Bot) showStandupRules(channel model.Project) string {
+	rules := []string{}
+	for _, rule := range standupRules(channel) {
+		requirement, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "standupSectionRequired",
+				Other: "required",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		if !rule.required {
+			requirement, err = bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "standupSectionOptional",
+					Other: "optional",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+		}
+		rules = append(rules, fmt.Sprintf("%s (%s): %s", rule.name, requirement, rule.keys()))
+	}
+
+	showStandupRules, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "showStandupRules",
+			Other: "Standup sections and their keywords:\n{{.Rules}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Rules": strings.Join(rules, "\n"),
+		},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return showStandupRules
+}
@@ -205,6 +205,7 @@ func (bot *Bot) showCommand(command slack.SlashCommand) string {
 
 	var list []string
 
+	//lateness is shown for standups submitted for today's deadline
 	for _, member := range members {
 		var role string
 		role = member.Role
@@ -212,6 +213,14 @@ func (bot *Bot) showCommand(command slack.SlashCommand) string {
 		if member.Role == "" {
 			role = "developer"
 		}
+
+		todayDeadline, hasDeadline := standupDeadline(bot.standuperProject(channel, member.UserID), time.Now())
+		if hasDeadline {
+			standup, err := bot.db.SelectLatestStandupByUser(member.UserID, member.ChannelID)
+			if err == nil && standup.DeadlineAt == todayDeadline.Unix() {
+				role += ", " + bot.latenessText(standup)
+			}
+		}
 		list = append(list, fmt.Sprintf("%s(%s)", member.RealName, role))
 	}
 
@@ -1,13 +1,16 @@
 package botuser
 
 import (
+	"strings"
+
+	"github.com/maddevsio/comedian/model"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
 )
 
 func (bot *Bot) modifySubmittionDays(command slack.SlashCommand) string {
-	submittionDays := command.Text
+	submittionDays := strings.TrimSpace(command.Text)
 
 	channel, err := bot.db.SelectProject(command.ChannelID)
 	if err != nil {
@@ -23,6 +26,19 @@ func (bot *Bot) modifySubmittionDays(command slack.SlashCommand) string {
 		return deadlineNotSet
 	}
 
+	if _, err := model.ParseSchedule(submittionDays); err != nil {
+		wrongSubmittionDays, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "wrongSubmittionDays",
+				Other: "Could not recognize submittion days. Use weekdays like `monday, thursday`, rules like `weekdays except wednesday`, `every other friday`, `first monday of the month` or cron like `cron 0 10 * * 1-5`, several rules are separated with `;`",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return wrongSubmittionDays
+	}
+
 	channel.SubmissionDays = submittionDays
 
 	_, err = bot.db.UpdateProject(channel)
@@ -0,0 +1,168 @@
+package botuser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+//profileTZTTL is how long timezones from Slack profiles are reused before asking Slack again
+const profileTZTTL = time.Hour
+
+//profileTZFailureTTL is how long Slack is not asked again after a failed lookup
+const profileTZFailureTTL = 5 * time.Minute
+
+type profileTZ struct {
+	tz        string
+	fetchedAt time.Time
+	ttl       time.Duration
+}
+
+//standuperProject returns project as the standuper sees it. In user deadline mode
+//deadline, submission days and standup dates are in the standuper timezone
+func (bot *Bot) standuperProject(project model.Project, userID string) model.Project {
+	if project.DeadlineMode != model.DeadlineUser {
+		return project
+	}
+	if tz := bot.standuperTZ(project.ChannelID, userID); tz != "" {
+		project.TZ = tz
+	}
+	return project
+}
+
+//standuperTZ returns timezone stored for the standuper or the one from their Slack profile
+func (bot *Bot) standuperTZ(channelID, userID string) string {
+	standuper, err := bot.db.FindStansuperByUserID(userID, channelID)
+	if err == nil && standuper.TZ != "" {
+		return standuper.TZ
+	}
+	return bot.profileTZ(userID)
+}
+
+//profileTZ returns timezone from Slack profile of the user, empty if it is unknown.
+//Slack is asked without holding the lock, failed lookups keep the last known timezone for a while
+func (bot *Bot) profileTZ(userID string) string {
+	bot.tzMutex.Lock()
+	cached, ok := bot.profileTZs[userID]
+	bot.tzMutex.Unlock()
+	if ok && time.Since(cached.fetchedAt) < cached.ttl {
+		return cached.tz
+	}
+
+	entry := profileTZ{tz: cached.tz, fetchedAt: time.Now(), ttl: profileTZFailureTTL}
+	user, err := bot.slack.GetUserInfo(userID)
+	if err != nil {
+		log.Error("GetUserInfo failed: ", err)
+	} else {
+		entry.tz, entry.ttl = user.TZ, profileTZTTL
+		if _, err := time.LoadLocation(entry.tz); err != nil {
+			entry.tz = ""
+		}
+	}
+
+	bot.tzMutex.Lock()
+	if bot.profileTZs == nil {
+		bot.profileTZs = map[string]profileTZ{}
+	}
+	bot.profileTZs[userID] = entry
+	bot.tzMutex.Unlock()
+	return entry.tz
+}
+
+//standupersByTZ groups project standupers by their timezones
+func (bot *Bot) standupersByTZ(project model.Project) (map[string]map[string]bool, error) {
+	standupers, err := bot.db.ListProjectStandupers(project.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := map[string]map[string]bool{}
+	for _, standuper := range standupers {
+		tz := standuper.TZ
+		if tz == "" {
+			tz = bot.profileTZ(standuper.UserID)
+		}
+		if tz == "" {
+			tz = project.TZ
+		}
+		if zones[tz] == nil {
+			zones[tz] = map[string]bool{}
+		}
+		zones[tz][standuper.UserID] = true
+	}
+	return zones, nil
+}
+
+//modifyDeadlineMode shows or changes whose timezone the project deadline is in
+func (bot *Bot) modifyDeadlineMode(command slack.SlashCommand) string {
+	channel, err := bot.db.SelectProject(command.ChannelID)
+	if err != nil {
+		deadlineModeNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:    "deadlineModeNotSet",
+				Other: "Could not change deadline mode",
+			},
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return deadlineModeNotSet
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(command.Text))
+	if mode != "" {
+		channel.DeadlineMode = mode
+		err = channel.Validate()
+		if err != nil {
+			wrongDeadlineMode, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "wrongDeadlineMode",
+					Other: "Unknown deadline mode, use one of: project, user",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return wrongDeadlineMode
+		}
+
+		channel, err = bot.db.UpdateProject(channel)
+		if err != nil {
+			log.Error(err)
+			deadlineModeNotSet, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "deadlineModeNotSet",
+					Other: "Could not change deadline mode",
+				},
+			})
+			if err != nil {
+				log.Error(err)
+			}
+			return deadlineModeNotSet
+		}
+	}
+
+	description := &i18n.Message{
+		ID:    "deadlineModeProject",
+		Other: "Deadline is the same moment for everyone, in {{.TZ}} timezone",
+	}
+	if channel.DeadlineMode == model.DeadlineUser {
+		description = &i18n.Message{
+			ID:    "deadlineModeUser",
+			Other: "Deadline is local to every standuper, in the timezone of their Slack profile",
+		}
+	}
+
+	deadlineMode, err := bot.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: description,
+		TemplateData:   map[string]interface{}{"TZ": channel.TZ},
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return deadlineMode
+}
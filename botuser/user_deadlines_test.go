@@ -0,0 +1,37 @@
+package botuser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStanduperProject(t *testing.T) {
+	bot := &Bot{}
+	project := model.Project{
+		ChannelID:      "chanID",
+		TZ:             "Asia/Bishkek",
+		Deadline:       "10am",
+		SubmissionDays: "monday, tuesday, wednesday, thursday, friday",
+	}
+
+	//project deadline mode does not look for standuper timezone at all
+	assert.Equal(t, project, bot.standuperProject(project, "userID"))
+	project.DeadlineMode = model.DeadlineProject
+	assert.Equal(t, project, bot.standuperProject(project, "userID"))
+
+	//the same deadline comes at different moments for standupers of different timezones
+	berlin := project
+	berlin.TZ = "Europe/Berlin"
+	now := time.Date(2019, 9, 2, 6, 0, 0, 0, time.UTC)
+
+	deadline, ok := standupDeadline(project, now)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, time.Date(2019, 9, 2, 4, 0, 0, 0, time.UTC).Unix(), deadline.Unix())
+
+	deadline, ok = standupDeadline(berlin, now)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, time.Date(2019, 9, 2, 8, 0, 0, 0, time.UTC).Unix(), deadline.Unix())
+}
@@ -15,6 +15,9 @@ type Config struct {
 	SlackVerificationToken string `envconfig:"SLACK_VERIFICATION_TOKEN" required:"false"`
 	UIurl                  string `envconfig:"UI_URL" required:"false"`
 	NotificationTime       int64  `envconfig:"NOTIFICATION_TIME" default:"1"`
+	JobGraceMinutes        int64  `envconfig:"JOB_GRACE_MINUTES" default:"15"`
+	ReplicaID              string `envconfig:"REPLICA_ID" required:"false"`
+	LeaseSeconds           int64  `envconfig:"LEASE_SECONDS" default:"180"`
 }
 
 // Get method processes env variables and fills Config struct
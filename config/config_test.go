@@ -11,6 +11,8 @@ func TestConfig(t *testing.T) {
 	os.Clearenv()
 	conf, err := Get()
 	assert.NoError(t, err)
+	assert.Equal(t, int64(15), conf.JobGraceMinutes)
+	assert.Equal(t, int64(180), conf.LeaseSeconds)
 
 	os.Setenv("DATABASE", "DB")
 	os.Setenv("HTTP_BIND_ADDR", "0.0.0.0:8080")
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `projects`
+    ADD `done_keys` VARCHAR(1000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+    ADD `planned_keys` VARCHAR(1000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+    ADD `blockers_keys` VARCHAR(1000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+    ADD `optional_sections` VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `projects`
+    DROP COLUMN `done_keys`,
+    DROP COLUMN `planned_keys`,
+    DROP COLUMN `blockers_keys`,
+    DROP COLUMN `optional_sections`;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `standup_conversations` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `channel_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `step` INTEGER NOT NULL,
+    `done` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `planned` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `blockers` TEXT COLLATE utf8mb4_unicode_ci NOT NULL
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `projects` ADD `dm_standup_time` VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `standup_conversations`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `projects` DROP COLUMN `dm_standup_time`;
+-- +goose StatementEnd
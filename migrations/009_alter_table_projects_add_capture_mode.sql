@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `projects`
+    ADD `capture_mode` VARCHAR(50) NOT NULL DEFAULT 'mention',
+    ADD `thread_ts` VARCHAR(255) NOT NULL DEFAULT '',
+    ADD `thread_date` VARCHAR(10) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `projects`
+    DROP COLUMN `capture_mode`,
+    DROP COLUMN `thread_ts`,
+    DROP COLUMN `thread_date`;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `standup_revisions` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `standup_id` INTEGER NOT NULL,
+    `action` VARCHAR(50) NOT NULL,
+    `comment` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `done` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `planned` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `blockers` TEXT COLLATE utf8mb4_unicode_ci NOT NULL
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `standups` ADD `retracted_at` INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `standup_revisions`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `standups` DROP COLUMN `retracted_at`;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `blockers` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `channel_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `standup_id` INTEGER NOT NULL,
+    `last_standup_id` INTEGER NOT NULL,
+    `text` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `status` VARCHAR(50) NOT NULL,
+    `mentioned_at` INTEGER NOT NULL,
+    `acknowledged_by` VARCHAR(255) NOT NULL DEFAULT '',
+    `acknowledged_at` INTEGER NOT NULL DEFAULT 0,
+    `resolved_by` VARCHAR(255) NOT NULL DEFAULT '',
+    `resolved_at` INTEGER NOT NULL DEFAULT 0,
+    `escalated_at` INTEGER NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `projects` ADD `blocker_escalation_days` INTEGER NOT NULL DEFAULT 3;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `blockers`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `projects` DROP COLUMN `blocker_escalation_days`;
+-- +goose StatementEnd
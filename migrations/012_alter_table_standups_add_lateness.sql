@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `standups` ADD `deadline_at` INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `standups` ADD `late_minutes` INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `standups` DROP COLUMN `deadline_at`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `standups` DROP COLUMN `late_minutes`;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `standups` ADD `standup_date` VARCHAR(10) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE `standups` s LEFT JOIN `projects` p ON p.channel_id = s.channel_id
+SET s.standup_date = DATE_FORMAT(IFNULL(CONVERT_TZ(FROM_UNIXTIME(s.created_at), @@session.time_zone, p.tz), FROM_UNIXTIME(s.created_at)), '%Y-%m-%d');
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE INDEX `standups_user_date` ON `standups` (`channel_id`, `user_id`, `standup_date`);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX `standups_user_date` ON `standups`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `standups` DROP COLUMN `standup_date`;
+-- +goose StatementEnd
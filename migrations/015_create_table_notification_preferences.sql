@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `notification_preferences` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `delivery` VARCHAR(50) NOT NULL DEFAULT 'channel',
+    `heads_up_minutes` INTEGER NOT NULL DEFAULT 0,
+    UNIQUE KEY `notification_preferences_user` (`workspace_id`, `user_id`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `notification_preferences`;
+-- +goose StatementEnd
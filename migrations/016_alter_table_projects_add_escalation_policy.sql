@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `projects` ADD `escalation_policy` TEXT COLLATE utf8mb4_unicode_ci NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `projects` DROP COLUMN `escalation_policy`;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `absences` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `date_from` VARCHAR(10) NOT NULL,
+    `date_to` VARCHAR(10) NOT NULL,
+    `reason` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    KEY `absences_user_dates` (`workspace_id`, `user_id`, `date_from`, `date_to`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `absences`;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `excuses` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `channel_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `date` VARCHAR(10) NOT NULL,
+    `kind` VARCHAR(10) NOT NULL,
+    `reason` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    `until` INTEGER NOT NULL DEFAULT 0,
+    KEY `excuses_channel_date` (`channel_id`, `date`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `excuses`;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `projects` ADD `deadline_mode` VARCHAR(10) NOT NULL DEFAULT 'project';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `projects` DROP COLUMN `deadline_mode`;
+-- +goose StatementEnd
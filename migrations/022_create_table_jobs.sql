@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `jobs` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `channel_id` VARCHAR(255) NOT NULL,
+    `kind` VARCHAR(50) NOT NULL,
+    `run_at` INTEGER NOT NULL,
+    `status` VARCHAR(10) NOT NULL,
+    `started_at` INTEGER NOT NULL DEFAULT 0,
+    `finished_at` INTEGER NOT NULL DEFAULT 0,
+    `error` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    UNIQUE KEY `jobs_run` (`workspace_id`, `kind`, `channel_id`, `run_at`),
+    KEY `jobs_status_run_at` (`workspace_id`, `status`, `run_at`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `jobs`;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `leases` (
+    `name` VARCHAR(255) NOT NULL PRIMARY KEY,
+    `holder` VARCHAR(255) NOT NULL,
+    `acquired_at` INTEGER NOT NULL,
+    `expires_at` INTEGER NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `leases`;
+-- +goose StatementEnd
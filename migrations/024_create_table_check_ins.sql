@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `check_ins` (
+    `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `created_at` INTEGER NOT NULL,
+    `workspace_id` VARCHAR(255) NOT NULL,
+    `channel_id` VARCHAR(255) NOT NULL,
+    `name` VARCHAR(50) NOT NULL,
+    `deadline` VARCHAR(50) NOT NULL,
+    `questions` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+    UNIQUE KEY `check_ins_channel_name` (`channel_id`, `name`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `check_ins`;
+-- +goose StatementEnd
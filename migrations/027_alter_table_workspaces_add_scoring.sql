@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `workspaces` ADD `scoring` TEXT COLLATE utf8mb4_unicode_ci NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `workspaces` DROP COLUMN `scoring`;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `projects`
+    ADD `reporting_channel` VARCHAR(255) NOT NULL DEFAULT '',
+    ADD `reporting_time` VARCHAR(255) NOT NULL DEFAULT '',
+    ADD `weekly_report_day` VARCHAR(255) NOT NULL DEFAULT '',
+    ADD `daily_reports_disabled` TINYINT NOT NULL DEFAULT 0,
+    ADD `weekly_reports_disabled` TINYINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `projects`
+    DROP COLUMN `reporting_channel`,
+    DROP COLUMN `reporting_time`,
+    DROP COLUMN `weekly_report_day`,
+    DROP COLUMN `daily_reports_disabled`,
+    DROP COLUMN `weekly_reports_disabled`;
+-- +goose StatementEnd
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseICS reads holidays from iCalendar (ICS) data. Every day of an event
+// becomes a separate holiday named after the event summary
+func ParseICS(data string) ([]Holiday, error) {
+	holidays := []Holiday{}
+	inEvent := false
+	var start, end, name string
+
+	for _, line := range unfoldICS(data) {
+		key, value := splitICSLine(line)
+		switch {
+		case key == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			start, end, name = "", "", ""
+		case key == "END" && value == "VEVENT":
+			inEvent = false
+			days, err := eventDays(start, end)
+			if err != nil {
+				return holidays, err
+			}
+			for _, day := range days {
+				holidays = append(holidays, Holiday{Date: day, Name: name})
+			}
+		case !inEvent:
+			continue
+		case key == "DTSTART":
+			start = value
+		case key == "DTEND":
+			end = value
+		case key == "SUMMARY":
+			name = unescapeICS(value)
+		}
+	}
+
+	if len(holidays) == 0 {
+		return holidays, errors.New("calendar has no events")
+	}
+	return holidays, nil
+}
+
+//unfoldICS joins long lines that are split into several ones starting with a space or a tab
+func unfoldICS(data string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.Replace(data, "\r\n", "\n", -1), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return lines
+}
+
+//splitICSLine splits "DTSTART;VALUE=DATE:20190101" into "DTSTART" and "20190101"
+func splitICSLine(line string) (string, string) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return line, ""
+	}
+	key := line[:i]
+	if j := strings.Index(key, ";"); j >= 0 {
+		key = key[:j]
+	}
+	return strings.ToUpper(key), strings.TrimSpace(line[i+1:])
+}
+
+func unescapeICS(text string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
+
+//eventDays returns days of event in YYYY-MM-DD format, end of all-day events is exclusive
+func eventDays(start, end string) ([]string, error) {
+	from, err := parseICSDate(start)
+	if err != nil {
+		return nil, err
+	}
+	if end == "" {
+		return []string{from.Format("2006-01-02")}, nil
+	}
+	to, err := parseICSDate(end)
+	if err != nil {
+		return nil, err
+	}
+
+	days := []string{from.Format("2006-01-02")}
+	for day := from.AddDate(0, 0, 1); day.Before(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format("2006-01-02"))
+	}
+	return days, nil
+}
+
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("wrong event date %v", value)
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return day, fmt.Errorf("wrong event date %v", value)
+	}
+	return day, nil
+}
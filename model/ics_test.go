@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseICS(t *testing.T) {
+	calendar := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20190101\r\n" +
+		"DTEND;VALUE=DATE:20190103\r\n" +
+		"SUMMARY:New Year\\, holidays\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20191003T000000Z\r\n" +
+		"SUMMARY:Tag der Deutschen\r\n" +
+		"  Einheit\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	holidays, err := ParseICS(calendar)
+	assert.NoError(t, err)
+	assert.Equal(t, []Holiday{
+		{Date: "2019-01-01", Name: "New Year, holidays"},
+		{Date: "2019-01-02", Name: "New Year, holidays"},
+		{Date: "2019-10-03", Name: "Tag der Deutschen Einheit"},
+	}, holidays)
+
+	_, err = ParseICS("BEGIN:VCALENDAR\nEND:VCALENDAR\n")
+	assert.EqualError(t, err, "calendar has no events")
+
+	_, err = ParseICS("BEGIN:VEVENT\nDTSTART:2019\nEND:VEVENT\n")
+	assert.EqualError(t, err, "wrong event date 2019")
+}
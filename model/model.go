@@ -1,8 +1,13 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/nlopes/slack"
@@ -10,28 +15,124 @@ import (
 
 // Standup model used for serialization/deserialization stored standups
 type Standup struct {
-	ID          int64  `db:"id" json:"id"`
-	CreatedAt   int64  `db:"created_at" json:"created_at"`
-	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
-	ChannelID   string `db:"channel_id" json:"channel_id"`
-	UserID      string `db:"user_id" json:"user_id"`
-	Comment     string `db:"comment" json:"comment"`
-	MessageTS   string `db:"message_ts" json:"message_ts"`
+	ID           int64  `db:"id" json:"id"`
+	CreatedAt    int64  `db:"created_at" json:"created_at"`
+	WorkspaceID  string `db:"workspace_id" json:"workspace_id"`
+	ChannelID    string `db:"channel_id" json:"channel_id"`
+	UserID       string `db:"user_id" json:"user_id"`
+	Comment      string `db:"comment" json:"comment"`
+	Done         string `db:"done" json:"done"`
+	Planned      string `db:"planned" json:"planned"`
+	Blockers     string `db:"blockers" json:"blockers"`
+	MessageTS    string `db:"message_ts" json:"message_ts"`
+	RetractedAt  int64  `db:"retracted_at" json:"retracted_at"`
+	DeadlineAt   int64  `db:"deadline_at" json:"deadline_at"`
+	LateMinutes  int    `db:"late_minutes" json:"late_minutes"`
+	BackfilledAt int64  `db:"backfilled_at" json:"backfilled_at"`
+	StandupDate  string `db:"standup_date" json:"standup_date"`
+	CheckIn      string `db:"check_in" json:"check_in"`
 }
 
 // Project model used for serialization/deserialization stored Projects
 type Project struct {
-	ID               int64  `db:"id" json:"id"`
-	CreatedAt        int64  `db:"created_at" json:"created_at"`
-	WorkspaceID      string `db:"workspace_id" json:"workspace_id"`
-	ChannelName      string `db:"channel_name" json:"channel_name"`
-	ChannelID        string `db:"channel_id" json:"channel_id"`
-	Deadline         string `db:"deadline" json:"deadline"`
-	TZ               string `db:"tz" json:"tz"`
-	OnbordingMessage string `db:"onbording_message" json:"onbording_message,omitempty"`
-	SubmissionDays   string `db:"submission_days" json:"submission_days,omitempty"`
+	ID                    int64            `db:"id" json:"id"`
+	CreatedAt             int64            `db:"created_at" json:"created_at"`
+	WorkspaceID           string           `db:"workspace_id" json:"workspace_id"`
+	ChannelName           string           `db:"channel_name" json:"channel_name"`
+	ChannelID             string           `db:"channel_id" json:"channel_id"`
+	Deadline              string           `db:"deadline" json:"deadline"`
+	TZ                    string           `db:"tz" json:"tz"`
+	OnbordingMessage      string           `db:"onbording_message" json:"onbording_message,omitempty"`
+	SubmissionDays        string           `db:"submission_days" json:"submission_days,omitempty"`
+	DoneKeys              string           `db:"done_keys" json:"done_keys"`
+	PlannedKeys           string           `db:"planned_keys" json:"planned_keys"`
+	BlockersKeys          string           `db:"blockers_keys" json:"blockers_keys"`
+	OptionalSections      string           `db:"optional_sections" json:"optional_sections"`
+	DMStandupTime         string           `db:"dm_standup_time" json:"dm_standup_time"`
+	CaptureMode           string           `db:"capture_mode" json:"capture_mode"`
+	ThreadTS              string           `db:"thread_ts" json:"thread_ts"`
+	ThreadDate            string           `db:"thread_date" json:"thread_date"`
+	BlockerEscalationDays int              `db:"blocker_escalation_days" json:"blocker_escalation_days"`
+	EscalationPolicy      EscalationPolicy `db:"escalation_policy" json:"escalation_policy"`
+	DeadlineMode          string           `db:"deadline_mode" json:"deadline_mode"`
+	ReportingChannel      string           `db:"reporting_channel" json:"reporting_channel"`
+	ReportingTime         string           `db:"reporting_time" json:"reporting_time"`
+	WeeklyReportDay       string           `db:"weekly_report_day" json:"weekly_report_day"`
+	DailyReportsDisabled  bool             `db:"daily_reports_disabled" json:"daily_reports_disabled"`
+	WeeklyReportsDisabled bool             `db:"weekly_reports_disabled" json:"weekly_reports_disabled"`
+	Holidays              []Holiday        `db:"-" json:"-"`
+	CheckIns              []CheckIn        `db:"-" json:"-"`
+	CheckInName           string           `db:"-" json:"-"`
 }
 
+// DefaultBlockerEscalationDays is the number of working days blockers of new projects
+// may stay open before project managers are notified
+const DefaultBlockerEscalationDays = 3
+
+// Deadline modes define whose timezone project deadline is in
+const (
+	// DeadlineProject deadline is the same moment for everyone, in project timezone
+	DeadlineProject = "project"
+	// DeadlineUser deadline is local to every standuper, in their own timezone
+	DeadlineUser = "user"
+)
+
+// Capture modes define which channel messages are treated as standups
+const (
+	// CaptureMention accepts messages that mention the bot
+	CaptureMention = "mention"
+	// CaptureAll accepts all top-level messages from standupers
+	CaptureAll = "all"
+	// CaptureThread accepts standupers replies in the daily thread created by the bot
+	CaptureThread = "thread"
+)
+
+// EscalationStep is a notification sent to audience about standupers who have not
+// submitted standup yet. Offset is counted in minutes from the deadline, negative
+// offsets are before the deadline
+type EscalationStep struct {
+	OffsetMinutes int    `json:"offset_minutes"`
+	Audience      string `json:"audience"`
+	Message       string `json:"message,omitempty"`
+}
+
+// EscalationPolicy is a ladder of notifications project sends when standups are missed.
+// It is stored as JSON, empty policy means workspace defaults
+type EscalationPolicy []EscalationStep
+
+// Escalation audiences
+const (
+	// AudienceUser reminds non reporters the way they have chosen in notification preferences
+	AudienceUser = "user"
+	// AudienceDM reminds non reporters in direct messages
+	AudienceDM = "dm"
+	// AudienceChannel mentions non reporters in the project channel
+	AudienceChannel = "channel"
+	// AudiencePM tells project managers who has not submitted standup
+	AudiencePM = "pm"
+	// AudienceReporting tells workspace reporting channel who has not submitted standup
+	AudienceReporting = "reporting"
+)
+
+// MaxEscalationOffset limits how far from the deadline escalation steps can be
+const MaxEscalationOffset = 24 * 60
+
+// StandupSections lists names of sections standup consists of
+var StandupSections = []string{"done", "planned", "blockers"}
+
+// Report sections workspaces choose for report entries on standupers
+const (
+	// ReportSectionWorklogs shows time the standuper logged
+	ReportSectionWorklogs = "worklogs"
+	// ReportSectionCommits shows commits the standuper pushed to the project
+	ReportSectionCommits = "commits"
+	// ReportSectionStandup shows standups the standuper submitted
+	ReportSectionStandup = "standup"
+)
+
+// ReportSections lists report sections in the order reports show them by default
+var ReportSections = []string{ReportSectionWorklogs, ReportSectionCommits, ReportSectionStandup}
+
 // Standuper model used for serialization/deserialization stored ChannelMembers
 type Standuper struct {
 	ID          int64  `db:"id" json:"id"`
@@ -42,23 +143,26 @@ type Standuper struct {
 	Role        string `db:"role" json:"role"`
 	RealName    string `db:"real_name" json:"real_name"`
 	ChannelName string `db:"channel_name" json:"channel_name"`
+	TZ          string `db:"tz" json:"tz"`
 }
 
 // Workspace is used for updating and storing different bot configuration parameters
 type Workspace struct {
-	ID                     int64  `db:"id" json:"id"`
-	CreatedAt              int64  `db:"created_at" json:"created_at"`
-	BotUserID              string `db:"bot_user_id" json:"bot_user_id"`
-	NotifierInterval       int    `db:"notifier_interval" json:"notifier_interval" `
-	Language               string `db:"language" json:"language" `
-	MaxReminders           int    `db:"max_reminders" json:"max_reminders" `
-	ReminderOffset         int64  `db:"reminder_offset" json:"reminder_offset" `
-	BotAccessToken         string `db:"bot_access_token" json:"bot_access_token" `
-	WorkspaceID            string `db:"workspace_id" json:"workspace_id" `
-	WorkspaceName          string `db:"workspace_name" json:"workspace_name" `
-	ReportingChannel       string `db:"reporting_channel" json:"reporting_channel"`
-	ReportingTime          string `db:"reporting_time" json:"reporting_time"`
-	ProjectsReportsEnabled bool   `db:"projects_reports_enabled" json:"projects_reports_enabled"`
+	ID                     int64   `db:"id" json:"id"`
+	CreatedAt              int64   `db:"created_at" json:"created_at"`
+	BotUserID              string  `db:"bot_user_id" json:"bot_user_id"`
+	NotifierInterval       int     `db:"notifier_interval" json:"notifier_interval" `
+	Language               string  `db:"language" json:"language" `
+	MaxReminders           int     `db:"max_reminders" json:"max_reminders" `
+	ReminderOffset         int64   `db:"reminder_offset" json:"reminder_offset" `
+	BotAccessToken         string  `db:"bot_access_token" json:"bot_access_token" `
+	WorkspaceID            string  `db:"workspace_id" json:"workspace_id" `
+	WorkspaceName          string  `db:"workspace_name" json:"workspace_name" `
+	ReportingChannel       string  `db:"reporting_channel" json:"reporting_channel"`
+	ReportingTime          string  `db:"reporting_time" json:"reporting_time"`
+	ProjectsReportsEnabled bool    `db:"projects_reports_enabled" json:"projects_reports_enabled"`
+	ReportSections         string  `db:"report_sections" json:"report_sections"`
+	Scoring                Scoring `db:"scoring" json:"scoring"`
 }
 
 // ServiceEvent event coming from services
@@ -106,6 +210,287 @@ type NotificationThread struct {
 	ReminderCounter  int    `db:"reminder_counter" json:"reminder_counter"`
 }
 
+// StandupConversation stores answers of a standup collected in direct messages
+type StandupConversation struct {
+	ID          int64  `db:"id" json:"id"`
+	CreatedAt   int64  `db:"created_at" json:"created_at"`
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+	ChannelID   string `db:"channel_id" json:"channel_id"`
+	UserID      string `db:"user_id" json:"user_id"`
+	Step        int    `db:"step" json:"step"`
+	Done        string `db:"done" json:"done"`
+	Planned     string `db:"planned" json:"planned"`
+	Blockers    string `db:"blockers" json:"blockers"`
+}
+
+// StandupRevision keeps standup content as it was before the standup was edited or retracted
+type StandupRevision struct {
+	ID        int64  `db:"id" json:"id"`
+	CreatedAt int64  `db:"created_at" json:"created_at"`
+	StandupID int64  `db:"standup_id" json:"standup_id"`
+	Action    string `db:"action" json:"action"`
+	Comment   string `db:"comment" json:"comment"`
+	Done      string `db:"done" json:"done"`
+	Planned   string `db:"planned" json:"planned"`
+	Blockers  string `db:"blockers" json:"blockers"`
+}
+
+// Punctuality summarizes how standuper submits standups relative to project deadline
+type Punctuality struct {
+	UserID             string  `json:"user_id"`
+	ChannelID          string  `json:"channel_id"`
+	Standups           int     `json:"standups"`
+	OnTime             int     `json:"on_time"`
+	Late               int     `json:"late"`
+	TotalLateMinutes   int     `json:"total_late_minutes"`
+	AverageLateMinutes float64 `json:"average_late_minutes"`
+	MaxLateMinutes     int     `json:"max_late_minutes"`
+}
+
+// NotificationPreference defines how standuper is reminded about standups in all
+// projects of the workspace
+type NotificationPreference struct {
+	ID             int64  `db:"id" json:"id"`
+	CreatedAt      int64  `db:"created_at" json:"created_at"`
+	WorkspaceID    string `db:"workspace_id" json:"workspace_id"`
+	UserID         string `db:"user_id" json:"user_id"`
+	Delivery       string `db:"delivery" json:"delivery"`
+	HeadsUpMinutes int64  `db:"heads_up_minutes" json:"heads_up_minutes"`
+}
+
+// Notification deliveries define where standuper is reminded about standups
+const (
+	// DeliveryChannel mentions standuper in the project channel
+	DeliveryChannel = "channel"
+	// DeliveryDM sends standuper a direct message
+	DeliveryDM = "dm"
+	// DeliveryBoth mentions standuper in the channel and sends a direct message
+	DeliveryBoth = "both"
+)
+
+// Absence is a period standuper is out of office. Dates are in YYYY-MM-DD format
+// and both are included in the period
+type Absence struct {
+	ID          int64  `db:"id" json:"id"`
+	CreatedAt   int64  `db:"created_at" json:"created_at"`
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+	UserID      string `db:"user_id" json:"user_id"`
+	DateFrom    string `db:"date_from" json:"date_from"`
+	DateTo      string `db:"date_to" json:"date_to"`
+	Reason      string `db:"reason" json:"reason"`
+}
+
+// Job is a scheduled run of a bot task, e.g. reminders of a project or the daily report.
+// Jobs are planned ahead and stored, so runs missed because of delays or restarts are
+// caught up within a grace window. Every job is claimed before it runs and runs at most once
+type Job struct {
+	ID          int64  `db:"id" json:"id"`
+	CreatedAt   int64  `db:"created_at" json:"created_at"`
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+	ChannelID   string `db:"channel_id" json:"channel_id"`
+	Kind        string `db:"kind" json:"kind"`
+	RunAt       int64  `db:"run_at" json:"run_at"`
+	Status      string `db:"status" json:"status"`
+	StartedAt   int64  `db:"started_at" json:"started_at"`
+	FinishedAt  int64  `db:"finished_at" json:"finished_at"`
+	Error       string `db:"error" json:"error"`
+}
+
+// Lease gives exclusive right to do some work, e.g. scheduled work of a workspace,
+// to one replica of the bot until it expires. Holder renews the lease while it is alive
+type Lease struct {
+	Name       string `db:"name" json:"name"`
+	Holder     string `db:"holder" json:"holder"`
+	AcquiredAt int64  `db:"acquired_at" json:"acquired_at"`
+	ExpiresAt  int64  `db:"expires_at" json:"expires_at"`
+}
+
+// Job kinds
+const (
+	// JobHeadsUp warns standupers about the coming deadline
+	JobHeadsUp = "heads_up"
+	// JobEscalation runs escalation steps of the project: alarms and reminders
+	JobEscalation = "escalation"
+	// JobSnoozeOver reminds standupers whose snooze is over
+	JobSnoozeOver = "snooze_over"
+	// JobDMStandup asks standupers for standups in direct messages
+	JobDMStandup = "dm_standup"
+	// JobDailyReport posts report on yesterday's standups
+	JobDailyReport = "daily_report"
+	// JobWeeklyReport posts report on the past week
+	JobWeeklyReport = "weekly_report"
+	// JobWorklogsReminder asks to check worklogs on the last day of month
+	JobWorklogsReminder = "worklogs_reminder"
+	// JobMonthlyReport posts rollups of the past month
+	JobMonthlyReport = "monthly_report"
+	// JobQuarterlyReport posts rollups of the past quarter
+	JobQuarterlyReport = "quarterly_report"
+)
+
+// Rollup periods
+const (
+	RollupMonth   = "month"
+	RollupQuarter = "quarter"
+)
+
+// Rollup sums up standups, worklogs and commits of a standuper in the project over
+// a month or a quarter. Submission rate is in percent, lateness is in minutes, worklogs are in seconds
+type Rollup struct {
+	UserID             string  `json:"user_id"`
+	RealName           string  `json:"real_name"`
+	ChannelID          string  `json:"channel_id"`
+	ChannelName        string  `json:"channel_name"`
+	Period             string  `json:"period"`
+	From               string  `json:"from"`
+	To                 string  `json:"to"`
+	ExpectedStandups   int     `json:"expected_standups"`
+	SubmittedStandups  int     `json:"submitted_standups"`
+	SubmissionRate     float64 `json:"submission_rate"`
+	LateStandups       int     `json:"late_standups"`
+	AverageLateMinutes float64 `json:"average_late_minutes"`
+	Worklogs           int     `json:"worklogs"`
+	ProjectWorklogs    int     `json:"project_worklogs"`
+	ExpectedWorklogs   int     `json:"expected_worklogs"`
+	Commits            int     `json:"commits"`
+	CollectorError     string  `json:"collector_error,omitempty"`
+}
+
+// Job statuses
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+	JobMissed  = "missed"
+)
+
+// Excuse lets standuper opt out of standup of a project for a day (skip) or
+// delay the day's reminders (snooze). Date is standup date in YYYY-MM-DD format
+type Excuse struct {
+	ID          int64  `db:"id" json:"id"`
+	CreatedAt   int64  `db:"created_at" json:"created_at"`
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+	ChannelID   string `db:"channel_id" json:"channel_id"`
+	UserID      string `db:"user_id" json:"user_id"`
+	Date        string `db:"date" json:"date"`
+	Kind        string `db:"kind" json:"kind"`
+	Reason      string `db:"reason" json:"reason"`
+	Until       int64  `db:"until" json:"until"`
+}
+
+// Excuse kinds
+const (
+	// ExcuseSkip excuses standuper from the standup of the day
+	ExcuseSkip = "skip"
+	// ExcuseSnooze delays reminders till Until
+	ExcuseSnooze = "snooze"
+)
+
+// CheckIn is a named standup of a project with its own deadline and questions, e.g.
+// morning plan at 10am and evening report at 6pm. Project deadline is the unnamed check-in.
+// Questions end with "?" or are separated with ";", their answers fill standup sections in order
+type CheckIn struct {
+	ID          int64  `db:"id" json:"id"`
+	CreatedAt   int64  `db:"created_at" json:"created_at"`
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+	ChannelID   string `db:"channel_id" json:"channel_id"`
+	Name        string `db:"name" json:"name"`
+	Deadline    string `db:"deadline" json:"deadline"`
+	Questions   string `db:"questions" json:"questions"`
+}
+
+// Holiday is a day project does not submit standups on, e.g. a public holiday
+// in the project country. Date is in YYYY-MM-DD format
+type Holiday struct {
+	ID          int64  `db:"id" json:"id"`
+	CreatedAt   int64  `db:"created_at" json:"created_at"`
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+	ChannelID   string `db:"channel_id" json:"channel_id"`
+	Date        string `db:"date" json:"date"`
+	Name        string `db:"name" json:"name"`
+}
+
+// Blocker is an issue standuper reported in blockers section of a standup.
+// It stays open while the standuper keeps mentioning it in the following standups
+type Blocker struct {
+	ID             int64  `db:"id" json:"id"`
+	CreatedAt      int64  `db:"created_at" json:"created_at"`
+	WorkspaceID    string `db:"workspace_id" json:"workspace_id"`
+	ChannelID      string `db:"channel_id" json:"channel_id"`
+	UserID         string `db:"user_id" json:"user_id"`
+	StandupID      int64  `db:"standup_id" json:"standup_id"`
+	LastStandupID  int64  `db:"last_standup_id" json:"last_standup_id"`
+	Text           string `db:"text" json:"text"`
+	Status         string `db:"status" json:"status"`
+	MentionedAt    int64  `db:"mentioned_at" json:"mentioned_at"`
+	AcknowledgedBy string `db:"acknowledged_by" json:"acknowledged_by"`
+	AcknowledgedAt int64  `db:"acknowledged_at" json:"acknowledged_at"`
+	ResolvedBy     string `db:"resolved_by" json:"resolved_by"`
+	ResolvedAt     int64  `db:"resolved_at" json:"resolved_at"`
+	EscalatedAt    int64  `db:"escalated_at" json:"escalated_at"`
+}
+
+// Add counts standup in punctuality summary. Retracted standups and standups
+// submitted without deadline are skipped
+func (p *Punctuality) Add(st Standup) {
+	if st.Retracted() || st.DeadlineAt == 0 {
+		return
+	}
+	p.Standups++
+	if !st.Late() {
+		p.OnTime++
+		return
+	}
+	p.Late++
+	p.TotalLateMinutes += st.LateMinutes
+	if st.LateMinutes > p.MaxLateMinutes {
+		p.MaxLateMinutes = st.LateMinutes
+	}
+	p.AverageLateMinutes = float64(p.TotalLateMinutes) / float64(p.Late)
+}
+
+// Blocker statuses
+const (
+	BlockerOpen         = "open"
+	BlockerAcknowledged = "acknowledged"
+	BlockerResolved     = "resolved"
+)
+
+// Revision actions
+const (
+	RevisionEdited    = "edited"
+	RevisionRetracted = "retracted"
+)
+
+// Retracted tells if standup message was deleted by its author
+func (st Standup) Retracted() bool {
+	return st.RetractedAt != 0
+}
+
+// Backfilled tells if standup was submitted later for one of the past days
+func (st Standup) Backfilled() bool {
+	return st.BackfilledAt != 0
+}
+
+// Late tells if standup was submitted after the project deadline.
+// Standups submitted when project had no deadline are never late
+func (st Standup) Late() bool {
+	return st.DeadlineAt != 0 && st.LateMinutes > 0
+}
+
+// Revision returns revision that keeps current standup content
+func (st Standup) Revision(action string) StandupRevision {
+	return StandupRevision{
+		CreatedAt: time.Now().Unix(),
+		StandupID: st.ID,
+		Action:    action,
+		Comment:   st.Comment,
+		Done:      st.Done,
+		Planned:   st.Planned,
+		Blockers:  st.Blockers,
+	}
+}
+
 // Validate validates Standup struct
 func (st Standup) Validate() error {
 	if st.WorkspaceID == "" {
@@ -164,7 +549,37 @@ func (bs Workspace) Validate() error {
 		return err
 	}
 
-	return nil
+	seen := map[string]bool{}
+	for _, section := range bs.ReportSectionNames() {
+		known := false
+		for _, name := range ReportSections {
+			known = known || name == section
+		}
+		if !known {
+			return fmt.Errorf("unknown report section %v", section)
+		}
+		if seen[section] {
+			return fmt.Errorf("report section %v is repeated", section)
+		}
+		seen[section] = true
+	}
+
+	return bs.Scoring.Validate()
+}
+
+// ReportSectionNames returns report sections the workspace has chosen in their order,
+// all sections in the default order if it has not chosen any
+func (bs Workspace) ReportSectionNames() []string {
+	names := []string{}
+	for _, name := range strings.Split(bs.ReportSections, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ReportSections
+	}
+	return names
 }
 
 // Validate validates Project struct
@@ -184,9 +599,96 @@ func (ch Project) Validate() error {
 		return err
 	}
 
+	for _, keys := range []string{ch.DoneKeys, ch.PlannedKeys, ch.BlockersKeys} {
+		for _, key := range SplitList(keys) {
+			//keyword lists are split by commas, a regular expression with a comma comes in halves
+			if strings.HasPrefix(key, "/") != strings.HasSuffix(key, "/") {
+				return fmt.Errorf("keyword %v is cut by a comma, regular expressions cannot contain commas", key)
+			}
+			if _, err := CompileKeyword(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, section := range SplitList(ch.OptionalSections) {
+		if !isStandupSection(section) {
+			return fmt.Errorf("unknown standup section %v", section)
+		}
+	}
+
+	switch ch.CaptureMode {
+	case "", CaptureMention, CaptureAll, CaptureThread:
+	default:
+		return fmt.Errorf("unknown capture mode %v", ch.CaptureMode)
+	}
+
+	if ch.BlockerEscalationDays < 0 {
+		return errors.New("blocker escalation days cannot be negative")
+	}
+
+	switch ch.DeadlineMode {
+	case "", DeadlineProject, DeadlineUser:
+	default:
+		return fmt.Errorf("unknown deadline mode %v", ch.DeadlineMode)
+	}
+
+	if _, err := ParseSchedule(ch.WeeklyReportDay); err != nil {
+		return fmt.Errorf("wrong weekly report day: %v", err)
+	}
+
+	return ch.EscalationPolicy.Validate()
+}
+
+// Validate validates EscalationPolicy
+func (p EscalationPolicy) Validate() error {
+	for i, step := range p {
+		switch step.Audience {
+		case AudienceUser, AudienceDM, AudienceChannel, AudiencePM, AudienceReporting:
+		default:
+			return fmt.Errorf("escalation step %v: unknown audience %v", i+1, step.Audience)
+		}
+		if step.OffsetMinutes < -MaxEscalationOffset || step.OffsetMinutes > MaxEscalationOffset {
+			return fmt.Errorf("escalation step %v: offset must be within %v minutes from the deadline", i+1, MaxEscalationOffset)
+		}
+		if _, err := template.New("step").Parse(step.Message); err != nil {
+			return fmt.Errorf("escalation step %v: wrong message template: %v", i+1, err)
+		}
+	}
 	return nil
 }
 
+// Value stores escalation policy as JSON
+func (p EscalationPolicy) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads escalation policy stored as JSON
+func (p *EscalationPolicy) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into escalation policy", src)
+	}
+	if len(data) == 0 {
+		*p = nil
+		return nil
+	}
+	return json.Unmarshal(data, p)
+}
+
 // Validate validates Standuper struct
 func (s Standuper) Validate() error {
 	if s.WorkspaceID == "" {
@@ -204,6 +706,12 @@ func (s Standuper) Validate() error {
 		return err
 	}
 
+	if s.TZ != "" {
+		if _, err := time.LoadLocation(s.TZ); err != nil {
+			return fmt.Errorf("unknown timezone %v", s.TZ)
+		}
+	}
+
 	return nil
 }
 
@@ -223,3 +731,311 @@ func (nt NotificationThread) Validate() error {
 	}
 	return nil
 }
+
+// CompileKeyword compiles standup keyword written as /expr/ into a regular expression
+// matched ignoring case, plain keywords give nil
+func CompileKeyword(key string) (*regexp.Regexp, error) {
+	if len(key) <= 2 || !strings.HasPrefix(key, "/") || !strings.HasSuffix(key, "/") {
+		return nil, nil
+	}
+	re, err := regexp.Compile("(?i)" + key[1:len(key)-1])
+	if err != nil {
+		return nil, fmt.Errorf("keyword %v is not a valid regular expression", key)
+	}
+	return re, nil
+}
+
+// SplitList splits comma separated list and trims its items
+func SplitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isStandupSection(name string) bool {
+	for _, section := range StandupSections {
+		if section == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates StandupConversation struct
+func (c StandupConversation) Validate() error {
+	if c.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if c.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if c.ChannelID == "" {
+		return errors.New("channel ID cannot be empty")
+	}
+	if c.Step < 0 || c.Step > len(StandupSections) {
+		return errors.New("conversation step is out of range")
+	}
+	return nil
+}
+
+// Validate validates StandupRevision struct
+func (r StandupRevision) Validate() error {
+	if r.StandupID <= 0 {
+		return errors.New("standup ID cannot be empty")
+	}
+	if r.Action != RevisionEdited && r.Action != RevisionRetracted {
+		return fmt.Errorf("unknown revision action %v", r.Action)
+	}
+	return nil
+}
+
+// Validate validates NotificationPreference struct
+func (p NotificationPreference) Validate() error {
+	if p.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	switch p.Delivery {
+	case DeliveryChannel, DeliveryDM, DeliveryBoth:
+	default:
+		return fmt.Errorf("unknown delivery %v", p.Delivery)
+	}
+	if p.HeadsUpMinutes < 0 {
+		return errors.New("heads up minutes cannot be negative")
+	}
+	return nil
+}
+
+// InChannel tells if standuper wants to be mentioned in the project channel
+func (p NotificationPreference) InChannel() bool {
+	return p.Delivery == DeliveryChannel || p.Delivery == DeliveryBoth
+}
+
+// InDM tells if standuper wants to get direct messages
+func (p NotificationPreference) InDM() bool {
+	return p.Delivery == DeliveryDM || p.Delivery == DeliveryBoth
+}
+
+// Validate validates Absence struct
+func (a Absence) Validate() error {
+	if a.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if a.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if _, err := time.Parse("2006-01-02", a.DateFrom); err != nil {
+		return fmt.Errorf("wrong start date %v, use YYYY-MM-DD format", a.DateFrom)
+	}
+	if _, err := time.Parse("2006-01-02", a.DateTo); err != nil {
+		return fmt.Errorf("wrong end date %v, use YYYY-MM-DD format", a.DateTo)
+	}
+	if a.DateTo < a.DateFrom {
+		return errors.New("absence cannot end before it starts")
+	}
+	return nil
+}
+
+// Covers tells if standuper is absent on the date in YYYY-MM-DD format
+func (a Absence) Covers(date string) bool {
+	return a.DateFrom <= date && date <= a.DateTo
+}
+
+// Validate validates Job struct
+func (j Job) Validate() error {
+	if j.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	switch j.Kind {
+	case JobDailyReport, JobWeeklyReport, JobWorklogsReminder, JobMonthlyReport, JobQuarterlyReport:
+	case JobHeadsUp, JobEscalation, JobSnoozeOver, JobDMStandup:
+		if j.ChannelID == "" {
+			return fmt.Errorf("%v job needs channel ID", j.Kind)
+		}
+	default:
+		return fmt.Errorf("unknown job kind %v", j.Kind)
+	}
+	if j.RunAt <= 0 {
+		return errors.New("job run time cannot be empty")
+	}
+	switch j.Status {
+	case JobPending, JobRunning, JobDone, JobFailed, JobMissed:
+	default:
+		return fmt.Errorf("unknown job status %v", j.Status)
+	}
+	return nil
+}
+
+// Key identifies job among the jobs of the workspace
+func (j Job) Key() string {
+	return fmt.Sprintf("%v/%v/%v", j.Kind, j.ChannelID, j.RunAt)
+}
+
+// Validate validates Excuse struct
+func (e Excuse) Validate() error {
+	if e.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if e.ChannelID == "" {
+		return errors.New("channel ID cannot be empty")
+	}
+	if e.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+		return fmt.Errorf("wrong date %v, use YYYY-MM-DD format", e.Date)
+	}
+	switch e.Kind {
+	case ExcuseSkip:
+	case ExcuseSnooze:
+		if e.Until <= e.CreatedAt {
+			return errors.New("snooze should end in the future")
+		}
+	default:
+		return fmt.Errorf("unknown excuse kind %v", e.Kind)
+	}
+	return nil
+}
+
+// Snoozed tells if excuse delays reminders at the moment
+func (e Excuse) Snoozed(now time.Time) bool {
+	return e.Kind == ExcuseSnooze && now.Unix() < e.Until
+}
+
+// Validate validates Holiday struct
+func (h Holiday) Validate() error {
+	if h.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if h.ChannelID == "" {
+		return errors.New("channel ID cannot be empty")
+	}
+	if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+		return fmt.Errorf("wrong holiday date %v, use YYYY-MM-DD format", h.Date)
+	}
+	return nil
+}
+
+// Validate validates CheckIn struct
+func (c CheckIn) Validate() error {
+	if c.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if c.ChannelID == "" {
+		return errors.New("channel ID cannot be empty")
+	}
+	if c.Name == "" || strings.ContainsAny(c.Name, " \t\n") {
+		return fmt.Errorf("wrong check-in name %q, use one word", c.Name)
+	}
+	if c.Deadline == "" {
+		return errors.New("check-in deadline cannot be empty")
+	}
+	if _, _, err := ParseTimeOfDay(c.Deadline); err != nil {
+		return fmt.Errorf("wrong check-in deadline: %v", err)
+	}
+	if len(c.QuestionList()) > len(StandupSections) {
+		return fmt.Errorf("check-in cannot have more than %v questions", len(StandupSections))
+	}
+	return nil
+}
+
+// QuestionList splits check-in questions into separate ones
+func (c CheckIn) QuestionList() []string {
+	questions := []string{}
+	for _, part := range strings.Split(c.Questions, ";") {
+		for part != "" {
+			end := strings.Index(part, "?") + 1
+			if end == 0 {
+				end = len(part)
+			}
+			if question := strings.TrimSpace(part[:end]); question != "" {
+				questions = append(questions, question)
+			}
+			part = part[end:]
+		}
+	}
+	return questions
+}
+
+// StandupCheckIns returns check-ins of the project: the unnamed one at the project
+// deadline and the named ones. Project without any has the unnamed check-in only
+func (ch Project) StandupCheckIns() []CheckIn {
+	checkIns := []CheckIn{}
+	if ch.Deadline != "" || len(ch.CheckIns) == 0 {
+		checkIns = append(checkIns, CheckIn{
+			WorkspaceID: ch.WorkspaceID,
+			ChannelID:   ch.ChannelID,
+			Deadline:    ch.Deadline,
+		})
+	}
+	return append(checkIns, ch.CheckIns...)
+}
+
+// AtCheckIn returns project as the check-in sees it: with deadline of the check-in
+func (ch Project) AtCheckIn(c CheckIn) Project {
+	ch.Deadline = c.Deadline
+	ch.CheckInName = c.Name
+	return ch
+}
+
+// IsHoliday tells if the date in YYYY-MM-DD format is in project holiday calendar
+func (ch Project) IsHoliday(date string) bool {
+	for _, holiday := range ch.Holidays {
+		if holiday.Date == date {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates Blocker struct
+func (b Blocker) Validate() error {
+	if b.WorkspaceID == "" {
+		return errors.New("workspace ID cannot be empty")
+	}
+	if b.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if b.ChannelID == "" {
+		return errors.New("channel ID cannot be empty")
+	}
+	if b.Text == "" {
+		return errors.New("blocker text cannot be empty")
+	}
+	switch b.Status {
+	case BlockerOpen, BlockerAcknowledged, BlockerResolved:
+	default:
+		return fmt.Errorf("unknown blocker status %v", b.Status)
+	}
+	return nil
+}
+
+// Acknowledge marks blocker as seen by someone who is going to help
+func (b *Blocker) Acknowledge(userID string, at int64) error {
+	if b.Status != BlockerOpen {
+		return fmt.Errorf("blocker is already %v", b.Status)
+	}
+	b.Status = BlockerAcknowledged
+	b.AcknowledgedBy = userID
+	b.AcknowledgedAt = at
+	return nil
+}
+
+// Resolve closes blocker. Blockers are resolved by standupers or automatically
+// when the author stops mentioning them, in that case userID is empty
+func (b *Blocker) Resolve(userID string, at int64) error {
+	if b.Status == BlockerResolved {
+		return fmt.Errorf("blocker is already %v", b.Status)
+	}
+	b.Status = BlockerResolved
+	b.ResolvedBy = userID
+	b.ResolvedAt = at
+	return nil
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -35,6 +36,215 @@ func TestStandup(t *testing.T) {
 	}
 }
 
+func TestStandupRevision(t *testing.T) {
+	testCases := []struct {
+		standupID    int64
+		action       string
+		errorMessage string
+	}{
+		{0, RevisionEdited, "standup ID cannot be empty"},
+		{1, "", "unknown revision action "},
+		{1, "deleted", "unknown revision action deleted"},
+		{1, RevisionEdited, ""},
+		{1, RevisionRetracted, ""},
+	}
+	for _, tt := range testCases {
+		r := Standup{ID: tt.standupID, Comment: "comment"}.Revision(tt.action)
+		assert.Equal(t, "comment", r.Comment)
+		err := r.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+}
+
+func TestStandupBackfilled(t *testing.T) {
+	assert.Equal(t, false, Standup{}.Backfilled())
+	assert.Equal(t, true, Standup{BackfilledAt: 1567411200}.Backfilled())
+}
+
+func TestPunctuality(t *testing.T) {
+	standups := []Standup{
+		{DeadlineAt: 0, LateMinutes: 30},
+		{DeadlineAt: 100, LateMinutes: -15},
+		{DeadlineAt: 100, LateMinutes: 0},
+		{DeadlineAt: 100, LateMinutes: 10},
+		{DeadlineAt: 100, LateMinutes: 50},
+		{DeadlineAt: 100, LateMinutes: 90, RetractedAt: 200},
+	}
+	p := Punctuality{}
+	for _, st := range standups {
+		p.Add(st)
+	}
+	assert.Equal(t, 4, p.Standups)
+	assert.Equal(t, 2, p.OnTime)
+	assert.Equal(t, 2, p.Late)
+	assert.Equal(t, 60, p.TotalLateMinutes)
+	assert.Equal(t, 30.0, p.AverageLateMinutes)
+	assert.Equal(t, 50, p.MaxLateMinutes)
+}
+
+func TestNotificationPreference(t *testing.T) {
+	testCases := []struct {
+		workspaceID    string
+		userID         string
+		delivery       string
+		headsUpMinutes int64
+		inChannel      bool
+		inDM           bool
+		errorMessage   string
+	}{
+		{"", "", "", 0, false, false, "workspace ID cannot be empty"},
+		{"workspaceID", "", "", 0, false, false, "user ID cannot be empty"},
+		{"workspaceID", "userID", "email", 0, false, false, "unknown delivery email"},
+		{"workspaceID", "userID", DeliveryDM, -5, false, true, "heads up minutes cannot be negative"},
+		{"workspaceID", "userID", DeliveryChannel, 0, true, false, ""},
+		{"workspaceID", "userID", DeliveryDM, 15, false, true, ""},
+		{"workspaceID", "userID", DeliveryBoth, 30, true, true, ""},
+	}
+	for _, tt := range testCases {
+		p := NotificationPreference{
+			WorkspaceID:    tt.workspaceID,
+			UserID:         tt.userID,
+			Delivery:       tt.delivery,
+			HeadsUpMinutes: tt.headsUpMinutes,
+		}
+		assert.Equal(t, tt.inChannel, p.InChannel())
+		assert.Equal(t, tt.inDM, p.InDM())
+		err := p.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+}
+
+func TestEscalationPolicy(t *testing.T) {
+	testCases := []struct {
+		policy       EscalationPolicy
+		errorMessage string
+	}{
+		{nil, ""},
+		{EscalationPolicy{{OffsetMinutes: -15, Audience: AudienceUser}, {OffsetMinutes: 0, Audience: AudienceChannel}, {OffsetMinutes: 60, Audience: AudiencePM}}, ""},
+		{EscalationPolicy{{OffsetMinutes: 0, Audience: AudienceDM}, {OffsetMinutes: 30, Audience: "everyone"}}, "escalation step 2: unknown audience everyone"},
+		{EscalationPolicy{{OffsetMinutes: 2000, Audience: AudienceReporting}}, "escalation step 1: offset must be within 1440 minutes from the deadline"},
+		{EscalationPolicy{{OffsetMinutes: 0, Audience: AudienceChannel, Message: "{{.Users"}}, "escalation step 1: wrong message template: template: step:1: unclosed action"},
+	}
+	for _, tt := range testCases {
+		err := tt.policy.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tt.errorMessage)
+		}
+
+		value, err := tt.policy.Value()
+		assert.NoError(t, err)
+
+		var scanned EscalationPolicy
+		assert.NoError(t, scanned.Scan([]byte(value.(string))))
+		assert.Equal(t, len(tt.policy), len(scanned))
+	}
+
+	var policy EscalationPolicy
+	assert.NoError(t, policy.Scan(nil))
+	assert.Equal(t, 0, len(policy))
+	assert.Error(t, policy.Scan(42))
+}
+
+func TestAbsence(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		userID       string
+		dateFrom     string
+		dateTo       string
+		errorMessage string
+	}{
+		{"", "", "", "", "workspace ID cannot be empty"},
+		{"workspaceID", "", "", "", "user ID cannot be empty"},
+		{"workspaceID", "userID", "tomorrow", "", "wrong start date tomorrow, use YYYY-MM-DD format"},
+		{"workspaceID", "userID", "2019-09-02", "2019-9-6", "wrong end date 2019-9-6, use YYYY-MM-DD format"},
+		{"workspaceID", "userID", "2019-09-06", "2019-09-02", "absence cannot end before it starts"},
+		{"workspaceID", "userID", "2019-09-02", "2019-09-02", ""},
+		{"workspaceID", "userID", "2019-09-02", "2019-09-06", ""},
+	}
+	for _, tt := range testCases {
+		a := Absence{
+			WorkspaceID: tt.workspaceID,
+			UserID:      tt.userID,
+			DateFrom:    tt.dateFrom,
+			DateTo:      tt.dateTo,
+		}
+		err := a.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tt.errorMessage)
+	}
+
+	a := Absence{DateFrom: "2019-09-02", DateTo: "2019-09-06"}
+	assert.Equal(t, false, a.Covers("2019-09-01"))
+	assert.Equal(t, true, a.Covers("2019-09-02"))
+	assert.Equal(t, true, a.Covers("2019-09-04"))
+	assert.Equal(t, true, a.Covers("2019-09-06"))
+	assert.Equal(t, false, a.Covers("2019-09-07"))
+}
+
+func TestBlocker(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		userID       string
+		channelID    string
+		text         string
+		status       string
+		errorMessage string
+	}{
+		{"", "", "", "", "", "workspace ID cannot be empty"},
+		{"workspaceID", "", "", "", "", "user ID cannot be empty"},
+		{"workspaceID", "userID", "", "", "", "channel ID cannot be empty"},
+		{"workspaceID", "userID", "channelID", "", "", "blocker text cannot be empty"},
+		{"workspaceID", "userID", "channelID", "text", "closed", "unknown blocker status closed"},
+		{"workspaceID", "userID", "channelID", "text", BlockerOpen, ""},
+	}
+	for _, tt := range testCases {
+		b := Blocker{
+			WorkspaceID: tt.workspaceID,
+			UserID:      tt.userID,
+			ChannelID:   tt.channelID,
+			Text:        tt.text,
+			Status:      tt.status,
+		}
+		err := b.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+}
+
+func TestBlockerLifecycle(t *testing.T) {
+	b := Blocker{Status: BlockerOpen}
+	assert.NoError(t, b.Acknowledge("pm", 10))
+	assert.Equal(t, BlockerAcknowledged, b.Status)
+	assert.Equal(t, "pm", b.AcknowledgedBy)
+	assert.Equal(t, errors.New("blocker is already acknowledged"), b.Acknowledge("pm", 11))
+
+	assert.NoError(t, b.Resolve("dev", 20))
+	assert.Equal(t, BlockerResolved, b.Status)
+	assert.Equal(t, int64(20), b.ResolvedAt)
+	assert.Equal(t, errors.New("blocker is already resolved"), b.Resolve("dev", 21))
+	assert.Equal(t, errors.New("blocker is already resolved"), b.Acknowledge("pm", 22))
+
+	b = Blocker{Status: BlockerOpen}
+	assert.NoError(t, b.Resolve("", 30))
+	assert.Equal(t, "", b.ResolvedBy)
+}
+
 func TestWorkspace(t *testing.T) {
 	testCases := []struct {
 		workspaceID   string
@@ -74,6 +284,40 @@ func TestWorkspace(t *testing.T) {
 	}
 }
 
+func TestWorkspaceReportSections(t *testing.T) {
+	bs := Workspace{
+		WorkspaceID:    "tID",
+		WorkspaceName:  "tName",
+		BotAccessToken: "accToken",
+		ReminderOffset: 1,
+		ReportingTime:  "01:00",
+		Language:       "en_US",
+	}
+
+	testCases := []struct {
+		sections     string
+		names        []string
+		errorMessage string
+	}{
+		{"", []string{"worklogs", "commits", "standup"}, ""},
+		{" , ", []string{"worklogs", "commits", "standup"}, ""},
+		{"standup", []string{"standup"}, ""},
+		{"Standup, worklogs", []string{"standup", "worklogs"}, ""},
+		{"standup,karma", []string{"standup", "karma"}, "unknown report section karma"},
+		{"commits,standup,commits", []string{"commits", "standup", "commits"}, "report section commits is repeated"},
+	}
+	for _, tt := range testCases {
+		bs.ReportSections = tt.sections
+		assert.Equal(t, tt.names, bs.ReportSectionNames())
+		err := bs.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, errors.New(tt.errorMessage), err)
+		}
+	}
+}
+
 func TestChannel(t *testing.T) {
 	testCases := []struct {
 		workspaceID  string
@@ -99,23 +343,133 @@ func TestChannel(t *testing.T) {
 	}
 }
 
+func TestProjectWeeklyReportDay(t *testing.T) {
+	ch := Project{WorkspaceID: "workspaceID", ChannelName: "chanName", ChannelID: "chanID"}
+	assert.NoError(t, ch.Validate())
+
+	ch.WeeklyReportDay = "friday"
+	assert.NoError(t, ch.Validate())
+
+	ch.WeeklyReportDay = "someday"
+	assert.Equal(t, errors.New("wrong weekly report day: unknown submission days someday"), ch.Validate())
+}
+
+func TestProjectLegacySubmissionDays(t *testing.T) {
+	//free text stored before schedules were parsed does not block project updates
+	for _, days := range []string{"mon-fri", "пн, вт"} {
+		ch := Project{WorkspaceID: "workspaceID", ChannelName: "chanName", ChannelID: "chanID", SubmissionDays: days}
+		assert.NoError(t, ch.Validate(), days)
+	}
+}
+
+func TestChannelStandupRules(t *testing.T) {
+	testCases := []struct {
+		doneKeys         string
+		blockersKeys     string
+		optionalSections string
+		errorMessage     string
+	}{
+		{"gestern, erledigt", "", "", ""},
+		{"/^erledigt/", "/probleme?/", "blockers", ""},
+		{"/erledigt(/", "", "", "keyword /erledigt(/ is not a valid regular expression"},
+		{"", "/[probleme/", "", "keyword /[probleme/ is not a valid regular expression"},
+		{"/erledigt{1,3}/", "", "", "keyword /erledigt{1 is cut by a comma, regular expressions cannot contain commas"},
+		{"/(?z)erledigt/", "", "", "keyword /(?z)erledigt/ is not a valid regular expression"},
+		{"", "", "blockers, planned", ""},
+		{"", "", "problems", "unknown standup section problems"},
+	}
+	for _, tt := range testCases {
+		ch := Project{
+			WorkspaceID:      "workspaceID",
+			ChannelName:      "chanName",
+			ChannelID:        "chanID",
+			DoneKeys:         tt.doneKeys,
+			BlockersKeys:     tt.blockersKeys,
+			OptionalSections: tt.optionalSections,
+		}
+		err := ch.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+}
+
+func TestChannelCaptureMode(t *testing.T) {
+	testCases := []struct {
+		captureMode  string
+		errorMessage string
+	}{
+		{"", ""},
+		{CaptureMention, ""},
+		{CaptureAll, ""},
+		{CaptureThread, ""},
+		{"reply", "unknown capture mode reply"},
+	}
+	for _, tt := range testCases {
+		ch := Project{
+			WorkspaceID: "workspaceID",
+			ChannelName: "chanName",
+			ChannelID:   "chanID",
+			CaptureMode: tt.captureMode,
+		}
+		err := ch.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+}
+
+func TestChannelDeadlineMode(t *testing.T) {
+	testCases := []struct {
+		deadlineMode string
+		errorMessage string
+	}{
+		{"", ""},
+		{DeadlineProject, ""},
+		{DeadlineUser, ""},
+		{"team", "unknown deadline mode team"},
+	}
+	for _, tt := range testCases {
+		ch := Project{
+			WorkspaceID:  "workspaceID",
+			ChannelName:  "chanName",
+			ChannelID:    "chanID",
+			DeadlineMode: tt.deadlineMode,
+		}
+		err := ch.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tt.errorMessage)
+	}
+}
+
 func TestStanduper(t *testing.T) {
 	testCases := []struct {
 		workspaceID  string
 		userID       string
 		channelID    string
+		tz           string
 		errorMessage string
 	}{
-		{"", "", "", "workspace ID cannot be empty"},
-		{"workspaceID", "", "", "user ID cannot be empty"},
-		{"workspaceID", "teamName", "", "channel ID cannot be empty"},
-		{"workspaceID", "userID", "accessToken", ""},
+		{"", "", "", "", "workspace ID cannot be empty"},
+		{"workspaceID", "", "", "", "user ID cannot be empty"},
+		{"workspaceID", "teamName", "", "", "channel ID cannot be empty"},
+		{"workspaceID", "userID", "accessToken", "", ""},
+		{"workspaceID", "userID", "accessToken", "Europe/Berlin", ""},
+		{"workspaceID", "userID", "accessToken", "Mars/Olympus", "unknown timezone Mars/Olympus"},
 	}
 	for _, tt := range testCases {
 		bs := Standuper{
 			WorkspaceID: tt.workspaceID,
 			UserID:      tt.userID,
 			ChannelID:   tt.channelID,
+			TZ:          tt.tz,
 		}
 		err := bs.Validate()
 		if err != nil {
@@ -124,6 +478,37 @@ func TestStanduper(t *testing.T) {
 	}
 }
 
+func TestStandupConversation(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		userID       string
+		channelID    string
+		step         int
+		errorMessage string
+	}{
+		{"", "", "", 0, "workspace ID cannot be empty"},
+		{"workspaceID", "", "", 0, "user ID cannot be empty"},
+		{"workspaceID", "userID", "", 0, "channel ID cannot be empty"},
+		{"workspaceID", "userID", "channelID", -1, "conversation step is out of range"},
+		{"workspaceID", "userID", "channelID", 4, "conversation step is out of range"},
+		{"workspaceID", "userID", "channelID", 1, ""},
+	}
+	for _, tt := range testCases {
+		c := StandupConversation{
+			WorkspaceID: tt.workspaceID,
+			UserID:      tt.userID,
+			ChannelID:   tt.channelID,
+			Step:        tt.step,
+		}
+		err := c.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+}
+
 func TestNotificationThread(t *testing.T) {
 	testCases := []struct {
 		channelid        string
@@ -151,3 +536,172 @@ func TestNotificationThread(t *testing.T) {
 		}
 	}
 }
+
+func TestHoliday(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		channelID    string
+		date         string
+		errorMessage string
+	}{
+		{"", "", "", "workspace ID cannot be empty"},
+		{"workspaceID", "", "", "channel ID cannot be empty"},
+		{"workspaceID", "channelID", "1 January", "wrong holiday date 1 January, use YYYY-MM-DD format"},
+		{"workspaceID", "channelID", "2019-01-01", ""},
+	}
+	for _, tt := range testCases {
+		h := Holiday{
+			WorkspaceID: tt.workspaceID,
+			ChannelID:   tt.channelID,
+			Date:        tt.date,
+		}
+		err := h.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tt.errorMessage)
+	}
+
+	project := Project{Holidays: []Holiday{{Date: "2019-01-01"}, {Date: "2019-03-08"}}}
+	assert.Equal(t, true, project.IsHoliday("2019-03-08"))
+	assert.Equal(t, false, project.IsHoliday("2019-03-09"))
+	assert.Equal(t, false, Project{}.IsHoliday("2019-03-08"))
+}
+
+func TestExcuse(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		channelID    string
+		userID       string
+		date         string
+		kind         string
+		until        int64
+		errorMessage string
+	}{
+		{"", "", "", "", "", 0, "workspace ID cannot be empty"},
+		{"workspaceID", "", "", "", "", 0, "channel ID cannot be empty"},
+		{"workspaceID", "channelID", "", "", "", 0, "user ID cannot be empty"},
+		{"workspaceID", "channelID", "userID", "today", "", 0, "wrong date today, use YYYY-MM-DD format"},
+		{"workspaceID", "channelID", "userID", "2019-09-02", "vacation", 0, "unknown excuse kind vacation"},
+		{"workspaceID", "channelID", "userID", "2019-09-02", ExcuseSnooze, 100, "snooze should end in the future"},
+		{"workspaceID", "channelID", "userID", "2019-09-02", ExcuseSnooze, 1900, ""},
+		{"workspaceID", "channelID", "userID", "2019-09-02", ExcuseSkip, 0, ""},
+	}
+	for _, tt := range testCases {
+		e := Excuse{
+			CreatedAt:   100,
+			WorkspaceID: tt.workspaceID,
+			ChannelID:   tt.channelID,
+			UserID:      tt.userID,
+			Date:        tt.date,
+			Kind:        tt.kind,
+			Until:       tt.until,
+		}
+		err := e.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tt.errorMessage)
+	}
+
+	snooze := Excuse{Kind: ExcuseSnooze, Until: 1900}
+	assert.Equal(t, true, snooze.Snoozed(time.Unix(1000, 0)))
+	assert.Equal(t, false, snooze.Snoozed(time.Unix(1900, 0)))
+	assert.Equal(t, false, Excuse{Kind: ExcuseSkip}.Snoozed(time.Unix(1000, 0)))
+}
+
+func TestJob(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		channelID    string
+		kind         string
+		runAt        int64
+		status       string
+		errorMessage string
+	}{
+		{"", "", "", 0, "", "workspace ID cannot be empty"},
+		{"workspaceID", "", "cleanup", 0, "", "unknown job kind cleanup"},
+		{"workspaceID", "", JobEscalation, 0, "", "escalation job needs channel ID"},
+		{"workspaceID", "", JobDailyReport, 0, "", "job run time cannot be empty"},
+		{"workspaceID", "", JobDailyReport, 1567400000, "queued", "unknown job status queued"},
+		{"workspaceID", "", JobDailyReport, 1567400000, JobPending, ""},
+		{"workspaceID", "channelID", JobHeadsUp, 1567400000, JobDone, ""},
+	}
+	for _, tt := range testCases {
+		j := Job{
+			WorkspaceID: tt.workspaceID,
+			ChannelID:   tt.channelID,
+			Kind:        tt.kind,
+			RunAt:       tt.runAt,
+			Status:      tt.status,
+		}
+		err := j.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tt.errorMessage)
+	}
+
+	assert.Equal(t, "escalation/chanID/1567400000", Job{Kind: JobEscalation, ChannelID: "chanID", RunAt: 1567400000}.Key())
+}
+
+func TestCheckIn(t *testing.T) {
+	testCases := []struct {
+		workspaceID  string
+		channelID    string
+		name         string
+		deadline     string
+		errorMessage string
+	}{
+		{"", "", "", "", "workspace ID cannot be empty"},
+		{"workspaceID", "", "", "", "channel ID cannot be empty"},
+		{"workspaceID", "channelID", "", "", `wrong check-in name "", use one word`},
+		{"workspaceID", "channelID", "evening report", "", `wrong check-in name "evening report", use one word`},
+		{"workspaceID", "channelID", "evening", "", "check-in deadline cannot be empty"},
+		{"workspaceID", "channelID", "evening", "foo", "wrong check-in deadline: wrong time of day foo"},
+		{"workspaceID", "channelID", "evening", "6pm", ""},
+	}
+	for _, tt := range testCases {
+		c := CheckIn{
+			WorkspaceID: tt.workspaceID,
+			ChannelID:   tt.channelID,
+			Name:        tt.name,
+			Deadline:    tt.deadline,
+		}
+		err := c.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.Equal(t, errors.New(tt.errorMessage), err)
+	}
+
+	c := CheckIn{WorkspaceID: "workspaceID", ChannelID: "channelID", Name: "evening", Deadline: "6pm"}
+	c.Questions = "What did you ship today? What is left for tomorrow?"
+	assert.Equal(t, []string{"What did you ship today?", "What is left for tomorrow?"}, c.QuestionList())
+	c.Questions = "Shipped today; Left for tomorrow; Blockers"
+	assert.Equal(t, []string{"Shipped today", "Left for tomorrow", "Blockers"}, c.QuestionList())
+	assert.NoError(t, c.Validate())
+	c.Questions = "One? Two? Three? Four?"
+	assert.Equal(t, errors.New("check-in cannot have more than 3 questions"), c.Validate())
+	c.Questions = ""
+	assert.Equal(t, []string{}, c.QuestionList())
+
+	project := Project{ChannelID: "channelID", Deadline: "10am"}
+	assert.Equal(t, []CheckIn{{ChannelID: "channelID", Deadline: "10am"}}, project.StandupCheckIns())
+
+	evening := CheckIn{ChannelID: "channelID", Name: "evening", Deadline: "6pm"}
+	project.CheckIns = []CheckIn{evening}
+	assert.Equal(t, 2, len(project.StandupCheckIns()))
+
+	view := project.AtCheckIn(evening)
+	assert.Equal(t, "6pm", view.Deadline)
+	assert.Equal(t, "evening", view.CheckInName)
+
+	//channel without deadline has named check-ins only
+	project.Deadline = ""
+	assert.Equal(t, []CheckIn{evening}, project.StandupCheckIns())
+}
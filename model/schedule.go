@@ -0,0 +1,331 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule tells which days are submission days of a project. It is parsed from
+// project submission days, rules separated with ";" add up:
+//   monday, wednesday, friday
+//   weekdays except wednesday
+//   every day, weekends
+//   every other friday, every other friday from 2019-09-06
+//   first monday of the month, last friday of the month
+//   cron 0 10 1-7 * 1 (day of month, month and day of week fields are used)
+type Schedule struct {
+	rules []scheduleRule
+}
+
+type scheduleRule interface {
+	includes(day time.Time) bool
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":      time.Sunday,
+	"sun":         time.Sunday,
+	"воскресенье": time.Sunday,
+	"monday":      time.Monday,
+	"mon":         time.Monday,
+	"понедельник": time.Monday,
+	"tuesday":     time.Tuesday,
+	"tue":         time.Tuesday,
+	"вторник":     time.Tuesday,
+	"wednesday":   time.Wednesday,
+	"wed":         time.Wednesday,
+	"среда":       time.Wednesday,
+	"thursday":    time.Thursday,
+	"thu":         time.Thursday,
+	"четверг":     time.Thursday,
+	"friday":      time.Friday,
+	"fri":         time.Friday,
+	"пятница":     time.Friday,
+	"saturday":    time.Saturday,
+	"sat":         time.Saturday,
+	"суббота":     time.Saturday,
+}
+
+var ordinals = map[string]int{
+	"first":  1,
+	"second": 2,
+	"third":  3,
+	"fourth": 4,
+	"last":   -1,
+}
+
+//biweeklyAnchor is the monday "every other" weeks are counted from by default
+var biweeklyAnchor = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// ParseSchedule parses project submission days. Empty text is a schedule without submission days
+func ParseSchedule(text string) (Schedule, error) {
+	schedule := Schedule{}
+	for _, part := range strings.Split(strings.ToLower(text), ";") {
+		part = strings.Join(strings.Fields(part), " ")
+		if part == "" {
+			continue
+		}
+		rule, err := parseScheduleRule(part)
+		if err != nil {
+			return schedule, err
+		}
+		schedule.rules = append(schedule.rules, rule)
+	}
+	return schedule, nil
+}
+
+// Includes tells if the day of t in its location is a submission day
+func (s Schedule) Includes(t time.Time) bool {
+	for _, rule := range s.rules {
+		if rule.includes(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseScheduleRule(text string) (scheduleRule, error) {
+	if strings.HasPrefix(text, "cron ") {
+		return parseCron(strings.TrimPrefix(text, "cron "))
+	}
+
+	if parts := strings.SplitN(text, " except ", 2); len(parts) == 2 {
+		rule, err := parseScheduleRule(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		days, err := parseWeekdays(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return exceptRule{rule: rule, days: days}, nil
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) >= 3 && fields[0] == "every" && fields[1] == "other" {
+		return parseBiweekly(fields[2:], text)
+	}
+	if ordinal, ok := ordinals[fields[0]]; ok {
+		return parseMonthly(ordinal, fields[1:], text)
+	}
+
+	return parseWeekdays(text)
+}
+
+//parseWeekdays parses list of weekdays like "monday, tuesday", "weekdays" or "every day"
+func parseWeekdays(text string) (weekdays, error) {
+	days := weekdays{}
+	switch text {
+	case "every day", "everyday", "daily":
+		text = "weekdays, weekends"
+	}
+
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for _, field := range fields {
+		switch field {
+		case "and", "every":
+			continue
+		case "weekdays":
+			for d := time.Monday; d <= time.Friday; d++ {
+				days[d] = true
+			}
+			continue
+		case "weekends":
+			days[time.Saturday], days[time.Sunday] = true, true
+			continue
+		}
+		day, ok := weekdayNames[strings.TrimSuffix(field, "s")]
+		if !ok {
+			day, ok = weekdayNames[field]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown submission days %v", text)
+		}
+		days[day] = true
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("unknown submission days %v", text)
+	}
+	return days, nil
+}
+
+//parseBiweekly parses "friday" or "friday from 2019-09-06" that follow "every other"
+func parseBiweekly(fields []string, text string) (scheduleRule, error) {
+	day, ok := weekdayNames[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown submission days %v", text)
+	}
+
+	anchor := biweeklyAnchor
+	switch {
+	case len(fields) == 3 && fields[1] == "from":
+		from, err := time.Parse("2006-01-02", fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("wrong schedule start date %v, use YYYY-MM-DD format", fields[2])
+		}
+		anchor = mondayOf(from)
+	case len(fields) != 1:
+		return nil, fmt.Errorf("unknown submission days %v", text)
+	}
+
+	return biweekly{day: day, anchor: anchor}, nil
+}
+
+//parseMonthly parses "monday of the month" that follows the ordinal
+func parseMonthly(ordinal int, fields []string, text string) (scheduleRule, error) {
+	if len(fields) < 3 || fields[1] != "of" || fields[len(fields)-1] != "month" {
+		return nil, fmt.Errorf("unknown submission days %v", text)
+	}
+	switch strings.Join(fields[2:len(fields)-1], " ") {
+	case "", "the", "every", "each":
+	default:
+		return nil, fmt.Errorf("unknown submission days %v", text)
+	}
+
+	day, ok := weekdayNames[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown submission days %v", text)
+	}
+	return monthly{ordinal: ordinal, day: day}, nil
+}
+
+//parseCron parses five cron fields, minute and hour are checked but not used
+func parseCron(text string) (scheduleRule, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %v should have 5 fields", text)
+	}
+
+	limits := [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	sets := make([]map[int]bool, 5)
+	for i, field := range fields {
+		set, err := parseCronField(field, limits[i][0], limits[i][1])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	if sets[4][7] {
+		sets[4][0] = true
+	}
+
+	return cron{
+		monthDays: sets[2],
+		months:    sets[3],
+		weekdays:  sets[4],
+		anyDay:    fields[2] == "*",
+		anyWeek:   fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("wrong cron field %v", field)
+			}
+			part = part[:i]
+		}
+
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			from, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("wrong cron field %v", field)
+			}
+			to = from
+			if len(bounds) == 2 {
+				to, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return nil, fmt.Errorf("wrong cron field %v", field)
+				}
+			}
+		}
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("wrong cron field %v", field)
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+//mondayOf returns date of monday of the week of t
+func mondayOf(t time.Time) time.Time {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+type weekdays map[time.Weekday]bool
+
+func (w weekdays) includes(day time.Time) bool {
+	return w[day.Weekday()]
+}
+
+type exceptRule struct {
+	rule scheduleRule
+	days weekdays
+}
+
+func (e exceptRule) includes(day time.Time) bool {
+	return e.rule.includes(day) && !e.days.includes(day)
+}
+
+type biweekly struct {
+	day    time.Weekday
+	anchor time.Time
+}
+
+func (b biweekly) includes(day time.Time) bool {
+	if day.Weekday() != b.day || mondayOf(day).Before(b.anchor) {
+		return false
+	}
+	weeks := int(mondayOf(day).Sub(b.anchor).Hours()/24) / 7
+	return weeks%2 == 0
+}
+
+type monthly struct {
+	ordinal int
+	day     time.Weekday
+}
+
+func (m monthly) includes(day time.Time) bool {
+	if day.Weekday() != m.day {
+		return false
+	}
+	if m.ordinal < 0 {
+		return day.AddDate(0, 0, 7).Month() != day.Month()
+	}
+	return (day.Day()-1)/7+1 == m.ordinal
+}
+
+type cron struct {
+	monthDays map[int]bool
+	months    map[int]bool
+	weekdays  map[int]bool
+	anyDay    bool
+	anyWeek   bool
+}
+
+func (c cron) includes(day time.Time) bool {
+	if !c.months[int(day.Month())] {
+		return false
+	}
+	inMonth, inWeek := c.monthDays[day.Day()], c.weekdays[int(day.Weekday())]
+	//like cron, restricted day of month and day of week match either of them
+	if !c.anyDay && !c.anyWeek {
+		return inMonth || inWeek
+	}
+	return inMonth && inWeek
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	//2019-09-02 is monday
+	day := func(d int) time.Time {
+		return time.Date(2019, 9, d, 10, 0, 0, 0, time.UTC)
+	}
+	includes := func(text string, days ...int) {
+		schedule, err := ParseSchedule(text)
+		require.NoError(t, err, text)
+		for d := 1; d <= 30; d++ {
+			expected := false
+			for _, included := range days {
+				expected = expected || d == included
+			}
+			assert.Equal(t, expected, schedule.Includes(day(d)), "%v on 2019-09-%02d", text, d)
+		}
+	}
+
+	includes("")
+	includes("monday, tuesday, wednesday, thursday, friday", 2, 3, 4, 5, 6, 9, 10, 11, 12, 13, 16, 17, 18, 19, 20, 23, 24, 25, 26, 27, 30)
+	includes("Mon, Wed and Fri", 2, 4, 6, 9, 11, 13, 16, 18, 20, 23, 25, 27, 30)
+	includes("weekdays except wednesday", 2, 3, 5, 6, 9, 10, 12, 13, 16, 17, 19, 20, 23, 24, 26, 27, 30)
+	includes("weekends", 1, 7, 8, 14, 15, 21, 22, 28, 29)
+	includes("every day except saturday, sunday", 2, 3, 4, 5, 6, 9, 10, 11, 12, 13, 16, 17, 18, 19, 20, 23, 24, 25, 26, 27, 30)
+	includes("every friday", 6, 13, 20, 27)
+	includes("every other friday", 13, 27)
+	includes("every other friday from 2019-09-06", 6, 20)
+	includes("every other friday from 2019-09-20", 20)
+	includes("first monday of the month", 2)
+	includes("last friday of the month; second tuesday of month", 10, 27)
+	includes("cron 0 10 * * 1-5/2", 2, 4, 6, 9, 11, 13, 16, 18, 20, 23, 25, 27, 30)
+	includes("cron 0 10 1,15 * 0", 1, 8, 15, 22, 29)
+	includes("cron 0 10 * 10 *")
+
+	for _, text := range []string{
+		"someday",
+		"weekdays except holidays",
+		"every other day",
+		"every other friday from friday",
+		"fifth monday of the month",
+		"first monday of the year",
+		"cron 0 10 * *",
+		"cron 0 10 32 * *",
+		"cron 0 10 * * mon",
+	} {
+		_, err := ParseSchedule(text)
+		assert.Error(t, err, text)
+	}
+}
@@ -0,0 +1,189 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Scoring tells how reports judge worklogs and commits of standupers. Workspace rules
+// are overridden by rules of standuper role and those by rules of the standuper,
+// rules left empty fall back to the defaults
+type Scoring struct {
+	ScoringRules
+	// Roles overrides rules for standupers of the role, like "developer" or "pm"
+	Roles map[string]ScoringRules `json:"roles,omitempty"`
+	// Standupers overrides rules for the user by slack user ID
+	Standupers map[string]ScoringRules `json:"standupers,omitempty"`
+}
+
+// ScoringRules are thresholds of daily and weekly worklogs in hours and of commits in the project
+type ScoringRules struct {
+	DailyWorklogs  Thresholds `json:"daily_worklogs,omitempty"`
+	WeeklyWorklogs Thresholds `json:"weekly_worklogs,omitempty"`
+	Commits        Thresholds `json:"commits,omitempty"`
+}
+
+// Threshold gives emoji and points to values from From up to From of the next threshold
+type Threshold struct {
+	From   int    `json:"from"`
+	Emoji  string `json:"emoji"`
+	Points int    `json:"points"`
+}
+
+// Thresholds are ordered by From
+type Thresholds []Threshold
+
+// DefaultScoring are the rules reports always used
+var DefaultScoring = ScoringRules{
+	DailyWorklogs: Thresholds{
+		{From: 0, Emoji: ":angry:"},
+		{From: 3, Emoji: ":disappointed:"},
+		{From: 7, Emoji: ":wink:", Points: 1},
+		{From: 9, Emoji: ":sunglasses:", Points: 1},
+	},
+	WeeklyWorklogs: Thresholds{
+		{From: 0, Emoji: ":disappointed:"},
+		{From: 31, Emoji: ":wink:", Points: 1},
+		{From: 35, Emoji: ":sunglasses:", Points: 1},
+	},
+	Commits: Thresholds{
+		{From: 0, Emoji: ":shit:"},
+		{From: 1, Emoji: ":wink:", Points: 1},
+	},
+}
+
+// RulesFor returns rules for the standuper of the role
+func (s Scoring) RulesFor(userID, role string) ScoringRules {
+	rules := s.Standupers[userID]
+	rules = rules.fallBackTo(s.Roles[role])
+	rules = rules.fallBackTo(s.ScoringRules)
+	return rules.fallBackTo(DefaultScoring)
+}
+
+func (r ScoringRules) fallBackTo(other ScoringRules) ScoringRules {
+	if len(r.DailyWorklogs) == 0 {
+		r.DailyWorklogs = other.DailyWorklogs
+	}
+	if len(r.WeeklyWorklogs) == 0 {
+		r.WeeklyWorklogs = other.WeeklyWorklogs
+	}
+	if len(r.Commits) == 0 {
+		r.Commits = other.Commits
+	}
+	return r
+}
+
+// Judge returns emoji and points of the threshold the value reaches, values below
+// all thresholds get neither
+func (t Thresholds) Judge(value int) (string, int) {
+	var emoji string
+	var points int
+	for _, threshold := range t {
+		if value < threshold.From {
+			break
+		}
+		emoji, points = threshold.Emoji, threshold.Points
+	}
+	return emoji, points
+}
+
+// MaxPoints returns the most points thresholds give
+func (t Thresholds) MaxPoints() int {
+	max := 0
+	for _, threshold := range t {
+		if threshold.Points > max {
+			max = threshold.Points
+		}
+	}
+	return max
+}
+
+// Expected returns the least value that gets the most points, what standupers are expected to reach
+func (t Thresholds) Expected() int {
+	max := t.MaxPoints()
+	for _, threshold := range t {
+		if threshold.Points == max {
+			return threshold.From
+		}
+	}
+	return 0
+}
+
+// Validate validates Scoring
+func (s Scoring) Validate() error {
+	if err := s.ScoringRules.Validate(); err != nil {
+		return err
+	}
+	for role, rules := range s.Roles {
+		if err := rules.Validate(); err != nil {
+			return fmt.Errorf("role %v: %v", role, err)
+		}
+	}
+	for userID, rules := range s.Standupers {
+		if err := rules.Validate(); err != nil {
+			return fmt.Errorf("standuper %v: %v", userID, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates ScoringRules
+func (r ScoringRules) Validate() error {
+	if err := r.DailyWorklogs.validate(); err != nil {
+		return fmt.Errorf("daily worklogs: %v", err)
+	}
+	if err := r.WeeklyWorklogs.validate(); err != nil {
+		return fmt.Errorf("weekly worklogs: %v", err)
+	}
+	if err := r.Commits.validate(); err != nil {
+		return fmt.Errorf("commits: %v", err)
+	}
+	return nil
+}
+
+func (t Thresholds) validate() error {
+	for i, threshold := range t {
+		if threshold.From < 0 {
+			return fmt.Errorf("threshold %v cannot start below zero", i+1)
+		}
+		if threshold.Points < 0 {
+			return fmt.Errorf("threshold %v cannot give negative points", i+1)
+		}
+		if i > 0 && threshold.From <= t[i-1].From {
+			return fmt.Errorf("threshold %v should start above threshold %v", i+1, i)
+		}
+	}
+	return nil
+}
+
+// Value stores scoring as JSON
+func (s Scoring) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "{}" {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+// Scan reads scoring stored as JSON
+func (s *Scoring) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into scoring", src)
+	}
+	if len(data) == 0 {
+		*s = Scoring{}
+		return nil
+	}
+	return json.Unmarshal(data, s)
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdsJudge(t *testing.T) {
+	testCases := []struct {
+		value  int
+		emoji  string
+		points int
+	}{
+		{0, ":angry:", 0},
+		{2, ":angry:", 0},
+		{3, ":disappointed:", 0},
+		{7, ":wink:", 1},
+		{8, ":wink:", 1},
+		{9, ":sunglasses:", 1},
+		{24, ":sunglasses:", 1},
+	}
+	for _, tt := range testCases {
+		emoji, points := DefaultScoring.DailyWorklogs.Judge(tt.value)
+		assert.Equal(t, tt.emoji, emoji)
+		assert.Equal(t, tt.points, points)
+	}
+
+	//values below all thresholds get nothing
+	emoji, points := Thresholds{{From: 2, Emoji: ":wink:", Points: 1}}.Judge(1)
+	assert.Equal(t, "", emoji)
+	assert.Equal(t, 0, points)
+	assert.Equal(t, 2, Thresholds{{From: 0}, {From: 4, Points: 2}, {From: 8, Points: 1}}.MaxPoints())
+
+	assert.Equal(t, 7, DefaultScoring.DailyWorklogs.Expected())
+	assert.Equal(t, 31, DefaultScoring.WeeklyWorklogs.Expected())
+	assert.Equal(t, 4, Thresholds{{From: 0}, {From: 4, Points: 2}, {From: 8, Points: 1}}.Expected())
+}
+
+func TestScoringRulesFor(t *testing.T) {
+	partTime := Thresholds{{From: 0, Emoji: ":disappointed:"}, {From: 4, Emoji: ":wink:", Points: 1}}
+	contract := Thresholds{{From: 0, Emoji: ":disappointed:"}, {From: 30, Emoji: ":wink:", Points: 1}}
+	noCommits := Thresholds{{From: 0, Points: 1}}
+
+	scoring := Scoring{
+		ScoringRules: ScoringRules{Commits: noCommits},
+		Roles: map[string]ScoringRules{
+			"qa": {DailyWorklogs: partTime},
+		},
+		Standupers: map[string]ScoringRules{
+			"UPART": {DailyWorklogs: partTime, WeeklyWorklogs: contract},
+		},
+	}
+
+	assert.Equal(t, ScoringRules{
+		DailyWorklogs:  DefaultScoring.DailyWorklogs,
+		WeeklyWorklogs: DefaultScoring.WeeklyWorklogs,
+		Commits:        noCommits,
+	}, scoring.RulesFor("UFULL", "developer"))
+
+	assert.Equal(t, ScoringRules{
+		DailyWorklogs:  partTime,
+		WeeklyWorklogs: DefaultScoring.WeeklyWorklogs,
+		Commits:        noCommits,
+	}, scoring.RulesFor("UFULL", "qa"))
+
+	assert.Equal(t, ScoringRules{
+		DailyWorklogs:  partTime,
+		WeeklyWorklogs: contract,
+		Commits:        noCommits,
+	}, scoring.RulesFor("UPART", "developer"))
+
+	assert.Equal(t, DefaultScoring, Scoring{}.RulesFor("UFULL", "developer"))
+}
+
+func TestScoringValidate(t *testing.T) {
+	testCases := []struct {
+		scoring      Scoring
+		errorMessage string
+	}{
+		{Scoring{}, ""},
+		{Scoring{ScoringRules: DefaultScoring}, ""},
+		{Scoring{ScoringRules: ScoringRules{DailyWorklogs: Thresholds{{From: -1}}}}, "daily worklogs: threshold 1 cannot start below zero"},
+		{Scoring{ScoringRules: ScoringRules{Commits: Thresholds{{From: 0, Points: -1}}}}, "commits: threshold 1 cannot give negative points"},
+		{Scoring{Roles: map[string]ScoringRules{"pm": {WeeklyWorklogs: Thresholds{{From: 30}, {From: 30}}}}}, "role pm: weekly worklogs: threshold 2 should start above threshold 1"},
+		{Scoring{Standupers: map[string]ScoringRules{"UPART": {DailyWorklogs: Thresholds{{From: 5}, {From: 4}}}}}, "standuper UPART: daily worklogs: threshold 2 should start above threshold 1"},
+	}
+	for _, tt := range testCases {
+		err := tt.scoring.Validate()
+		if tt.errorMessage == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, errors.New(tt.errorMessage), err)
+		}
+	}
+}
+
+func TestScoringValue(t *testing.T) {
+	value, err := Scoring{}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	scoring := Scoring{Roles: map[string]ScoringRules{"qa": {Commits: Thresholds{{From: 0, Points: 1}}}}}
+	value, err = scoring.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"roles":{"qa":{"commits":[{"from":0,"emoji":"","points":1}]}}}`, value)
+
+	var scanned Scoring
+	assert.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, scoring, scanned)
+	assert.NoError(t, scanned.Scan(""))
+	assert.Equal(t, Scoring{}, scanned)
+}
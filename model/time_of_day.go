@@ -0,0 +1,29 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/olebedev/when"
+	"github.com/olebedev/when/rules/en"
+	"github.com/olebedev/when/rules/ru"
+)
+
+// ParseTimeOfDay parses time of day like 10am, 10:00 or "в 10 утра" and returns its hour and minute
+func ParseTimeOfDay(text string) (int, int, error) {
+	if text == "" {
+		return 0, 0, errors.New("time of day cannot be empty")
+	}
+
+	w := when.New(nil)
+	w.Add(en.All...)
+	w.Add(ru.All...)
+
+	base := time.Date(2019, 9, 2, 0, 0, 0, 0, time.UTC)
+	r, err := w.Parse(text, base)
+	if err != nil || r == nil {
+		return 0, 0, fmt.Errorf("wrong time of day %v", text)
+	}
+	return r.Time.Hour(), r.Time.Minute(), nil
+}
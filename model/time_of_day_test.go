@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	hour, minute, err := ParseTimeOfDay("10:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, hour)
+	assert.Equal(t, 30, minute)
+
+	hour, _, err = ParseTimeOfDay("2pm")
+	assert.NoError(t, err)
+	assert.Equal(t, 14, hour)
+
+	_, _, err = ParseTimeOfDay("foo")
+	assert.Error(t, err)
+
+	_, _, err = ParseTimeOfDay("")
+	assert.Error(t, err)
+}
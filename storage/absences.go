@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateAbsence creates absence entry in database
+func (m *DB) CreateAbsence(a model.Absence) (model.Absence, error) {
+	err := a.Validate()
+	if err != nil {
+		return a, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO absences (
+			created_at,
+			workspace_id, 
+			user_id, 
+			date_from, 
+			date_to, 
+			reason
+		) VALUES (?, ?, ?, ?, ?, ?)`,
+		a.CreatedAt,
+		a.WorkspaceID,
+		a.UserID,
+		a.DateFrom,
+		a.DateTo,
+		a.Reason,
+	)
+	if err != nil {
+		return a, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return a, err
+	}
+	a.ID = id
+
+	return a, nil
+}
+
+// UpdateAbsence updates absence period and reason
+func (m *DB) UpdateAbsence(a model.Absence) (model.Absence, error) {
+	err := a.Validate()
+	if err != nil {
+		return a, err
+	}
+
+	_, err = m.db.Exec(
+		"UPDATE `absences` SET date_from=?, date_to=?, reason=? WHERE id=?",
+		a.DateFrom, a.DateTo, a.Reason, a.ID,
+	)
+	return a, err
+}
+
+// GetAbsence returns absence by its ID
+func (m *DB) GetAbsence(id int64) (model.Absence, error) {
+	var a model.Absence
+	err := m.db.Get(&a, "SELECT * FROM `absences` WHERE id=?", id)
+	return a, err
+}
+
+// ListAbsences returns absences of workspace users, the latest first
+func (m *DB) ListAbsences(workspaceID string) ([]model.Absence, error) {
+	items := []model.Absence{}
+	err := m.db.Select(&items, "SELECT * FROM `absences` WHERE workspace_id=? ORDER BY date_from DESC", workspaceID)
+	return items, err
+}
+
+// ListUserAbsences returns user absences that end on the date or later
+func (m *DB) ListUserAbsences(workspaceID, userID, date string) ([]model.Absence, error) {
+	items := []model.Absence{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `absences` WHERE workspace_id=? AND user_id=? AND date_to>=? ORDER BY date_from",
+		workspaceID, userID, date,
+	)
+	return items, err
+}
+
+// SelectAbsence returns user absence that covers the date
+func (m *DB) SelectAbsence(workspaceID, userID, date string) (model.Absence, error) {
+	var a model.Absence
+	err := m.db.Get(&a,
+		`SELECT * FROM absences 
+		WHERE workspace_id=? AND user_id=? AND date_from<=? AND date_to>=? 
+		ORDER BY id DESC LIMIT 1`,
+		workspaceID, userID, date, date,
+	)
+	return a, err
+}
+
+// DeleteAbsence deletes absence entry from database
+func (m *DB) DeleteAbsence(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `absences` WHERE id=?", id)
+	return err
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsences(t *testing.T) {
+	_, err := db.CreateAbsence(model.Absence{})
+	assert.Error(t, err)
+
+	a, err := db.CreateAbsence(model.Absence{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		DateFrom:    "2019-09-02",
+		DateTo:      "2019-09-06",
+		Reason:      "vacation",
+	})
+	require.NoError(t, err)
+
+	selected, err := db.SelectAbsence("foo", "bar", "2019-09-04")
+	require.NoError(t, err)
+	assert.Equal(t, a.ID, selected.ID)
+	assert.Equal(t, "vacation", selected.Reason)
+
+	_, err = db.SelectAbsence("foo", "bar", "2019-09-07")
+	assert.Error(t, err)
+
+	_, err = db.SelectAbsence("foo", "baz", "2019-09-04")
+	assert.Error(t, err)
+
+	absences, err := db.ListUserAbsences("foo", "bar", "2019-09-06")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(absences))
+
+	absences, err = db.ListUserAbsences("foo", "bar", "2019-09-07")
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(absences))
+
+	a.DateTo = "2019-09-09"
+	_, err = db.UpdateAbsence(a)
+	require.NoError(t, err)
+
+	_, err = db.SelectAbsence("foo", "bar", "2019-09-07")
+	assert.NoError(t, err)
+
+	absences, err = db.ListAbsences("foo")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(absences))
+
+	assert.NoError(t, db.DeleteAbsence(a.ID))
+
+	_, err = db.GetAbsence(a.ID)
+	assert.Error(t, err)
+}
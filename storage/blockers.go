@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateBlocker creates blocker entry in database
+func (m *DB) CreateBlocker(b model.Blocker) (model.Blocker, error) {
+	err := b.Validate()
+	if err != nil {
+		return b, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO blockers (
+			created_at,
+			workspace_id, 
+			channel_id, 
+			user_id, 
+			standup_id, 
+			last_standup_id, 
+			text, 
+			status, 
+			mentioned_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		b.CreatedAt,
+		b.WorkspaceID,
+		b.ChannelID,
+		b.UserID,
+		b.StandupID,
+		b.LastStandupID,
+		b.Text,
+		b.Status,
+		b.MentionedAt,
+	)
+	if err != nil {
+		return b, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return b, err
+	}
+	b.ID = id
+
+	return b, nil
+}
+
+// UpdateBlocker updates blocker entry in database
+func (m *DB) UpdateBlocker(b model.Blocker) (model.Blocker, error) {
+	err := b.Validate()
+	if err != nil {
+		return b, err
+	}
+
+	_, err = m.db.Exec(
+		`UPDATE blockers SET 
+		last_standup_id=?,
+		text=?,
+		status=?,
+		mentioned_at=?,
+		acknowledged_by=?,
+		acknowledged_at=?,
+		resolved_by=?,
+		resolved_at=?,
+		escalated_at=? 
+		WHERE id=?`,
+		b.LastStandupID,
+		b.Text,
+		b.Status,
+		b.MentionedAt,
+		b.AcknowledgedBy,
+		b.AcknowledgedAt,
+		b.ResolvedBy,
+		b.ResolvedAt,
+		b.EscalatedAt,
+		b.ID,
+	)
+	return b, err
+}
+
+// GetBlocker returns blocker by its ID
+func (m *DB) GetBlocker(id int64) (model.Blocker, error) {
+	var b model.Blocker
+	err := m.db.Get(&b, "SELECT * FROM `blockers` WHERE id=?", id)
+	return b, err
+}
+
+// ListWorkspaceBlockers returns workspace blockers, all of them if status is empty
+func (m *DB) ListWorkspaceBlockers(workspaceID, status string) ([]model.Blocker, error) {
+	items := []model.Blocker{}
+	if status == "" {
+		err := m.db.Select(&items, "SELECT * FROM `blockers` WHERE workspace_id=? order by id desc", workspaceID)
+		return items, err
+	}
+	err := m.db.Select(&items, "SELECT * FROM `blockers` WHERE workspace_id=? AND status=? order by id desc", workspaceID, status)
+	return items, err
+}
+
+// ListProjectUnresolvedBlockers returns blockers of the channel that are not resolved yet
+func (m *DB) ListProjectUnresolvedBlockers(channelID string) ([]model.Blocker, error) {
+	items := []model.Blocker{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `blockers` WHERE channel_id=? AND status<>? order by id",
+		channelID, model.BlockerResolved,
+	)
+	return items, err
+}
+
+// ListUserUnresolvedBlockers returns blockers of the standuper that are not resolved yet
+func (m *DB) ListUserUnresolvedBlockers(userID, channelID string) ([]model.Blocker, error) {
+	items := []model.Blocker{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `blockers` WHERE user_id=? AND channel_id=? AND status<>? order by id",
+		userID, channelID, model.BlockerResolved,
+	)
+	return items, err
+}
+
+// DeleteBlocker deletes blocker entry from database
+func (m *DB) DeleteBlocker(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `blockers` WHERE id=?", id)
+	return err
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockers(t *testing.T) {
+	_, err := db.CreateBlocker(model.Blocker{})
+	assert.Error(t, err)
+
+	b, err := db.CreateBlocker(model.Blocker{
+		CreatedAt:     time.Now().Unix(),
+		WorkspaceID:   "foo",
+		ChannelID:     "bar12",
+		UserID:        "bar",
+		StandupID:     1,
+		LastStandupID: 1,
+		Text:          "waiting for staging access",
+		Status:        model.BlockerOpen,
+		MentionedAt:   time.Now().Unix(),
+	})
+	assert.NoError(t, err)
+
+	blockers, err := db.ListUserUnresolvedBlockers("bar", "bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(blockers))
+
+	blockers, err = db.ListWorkspaceBlockers("foo", model.BlockerOpen)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(blockers))
+
+	assert.NoError(t, b.Acknowledge("pm", time.Now().Unix()))
+	_, err = db.UpdateBlocker(b)
+	assert.NoError(t, err)
+
+	b, err = db.GetBlocker(b.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.BlockerAcknowledged, b.Status)
+	assert.Equal(t, "pm", b.AcknowledgedBy)
+
+	blockers, err = db.ListProjectUnresolvedBlockers("bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(blockers))
+
+	assert.NoError(t, b.Resolve("bar", time.Now().Unix()))
+	_, err = db.UpdateBlocker(b)
+	assert.NoError(t, err)
+
+	blockers, err = db.ListUserUnresolvedBlockers("bar", "bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(blockers))
+
+	blockers, err = db.ListWorkspaceBlockers("foo", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(blockers))
+
+	assert.NoError(t, db.DeleteBlocker(b.ID))
+}
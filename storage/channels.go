@@ -11,6 +11,10 @@ func (m *DB) CreateProject(ch model.Project) (model.Project, error) {
 		return ch, err
 	}
 
+	if ch.BlockerEscalationDays == 0 {
+		ch.BlockerEscalationDays = model.DefaultBlockerEscalationDays
+	}
+
 	res, err := m.db.Exec(
 		`INSERT INTO projects (
 			created_at,
@@ -20,8 +24,24 @@ func (m *DB) CreateProject(ch model.Project) (model.Project, error) {
 			deadline,
 			tz,
 			onbording_message,
-			submission_days
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			submission_days,
+			done_keys,
+			planned_keys,
+			blockers_keys,
+			optional_sections,
+			dm_standup_time,
+			capture_mode,
+			thread_ts,
+			thread_date,
+			blocker_escalation_days,
+			escalation_policy,
+			deadline_mode,
+			reporting_channel,
+			reporting_time,
+			weekly_report_day,
+			daily_reports_disabled,
+			weekly_reports_disabled
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		ch.CreatedAt,
 		ch.WorkspaceID,
 		ch.ChannelName,
@@ -30,6 +50,22 @@ func (m *DB) CreateProject(ch model.Project) (model.Project, error) {
 		ch.TZ,
 		ch.OnbordingMessage,
 		ch.SubmissionDays,
+		ch.DoneKeys,
+		ch.PlannedKeys,
+		ch.BlockersKeys,
+		ch.OptionalSections,
+		ch.DMStandupTime,
+		captureMode(ch),
+		ch.ThreadTS,
+		ch.ThreadDate,
+		ch.BlockerEscalationDays,
+		ch.EscalationPolicy,
+		deadlineMode(ch),
+		ch.ReportingChannel,
+		ch.ReportingTime,
+		ch.WeeklyReportDay,
+		ch.DailyReportsDisabled,
+		ch.WeeklyReportsDisabled,
 	)
 	if err != nil {
 		return ch, err
@@ -54,12 +90,44 @@ func (m *DB) UpdateProject(ch model.Project) (model.Project, error) {
 		deadline=?,
 		tz=?,
 		onbording_message=?,
-		submission_days=? 
+		submission_days=?,
+		done_keys=?,
+		planned_keys=?,
+		blockers_keys=?,
+		optional_sections=?,
+		dm_standup_time=?,
+		capture_mode=?,
+		thread_ts=?,
+		thread_date=?,
+		blocker_escalation_days=?,
+		escalation_policy=?,
+		deadline_mode=?,
+		reporting_channel=?,
+		reporting_time=?,
+		weekly_report_day=?,
+		daily_reports_disabled=?,
+		weekly_reports_disabled=?
 		WHERE id=?`,
 		ch.Deadline,
 		ch.TZ,
 		ch.OnbordingMessage,
 		ch.SubmissionDays,
+		ch.DoneKeys,
+		ch.PlannedKeys,
+		ch.BlockersKeys,
+		ch.OptionalSections,
+		ch.DMStandupTime,
+		captureMode(ch),
+		ch.ThreadTS,
+		ch.ThreadDate,
+		ch.BlockerEscalationDays,
+		ch.EscalationPolicy,
+		deadlineMode(ch),
+		ch.ReportingChannel,
+		ch.ReportingTime,
+		ch.WeeklyReportDay,
+		ch.DailyReportsDisabled,
+		ch.WeeklyReportsDisabled,
 		ch.ID,
 	)
 	if err != nil {
@@ -68,10 +136,24 @@ func (m *DB) UpdateProject(ch model.Project) (model.Project, error) {
 	return ch, nil
 }
 
-//ListProjects returns list of projects
+//ListProjects returns list of projects with their holiday calendars and check-ins
 func (m *DB) ListProjects() ([]model.Project, error) {
 	projects := []model.Project{}
 	err := m.db.Select(&projects, "SELECT * FROM `projects`")
+	if err != nil {
+		return projects, err
+	}
+
+	holidays := []model.Holiday{}
+	err = m.db.Select(&holidays, "SELECT * FROM `holidays`")
+	if err != nil {
+		return projects, err
+	}
+	attachHolidays(projects, holidays)
+
+	checkIns := []model.CheckIn{}
+	err = m.db.Select(&checkIns, "SELECT * FROM `check_ins` ORDER BY id")
+	attachCheckIns(projects, checkIns)
 	return projects, err
 }
 
@@ -79,6 +161,20 @@ func (m *DB) ListProjects() ([]model.Project, error) {
 func (m *DB) ListWorkspaceProjects(ws string) ([]model.Project, error) {
 	projects := []model.Project{}
 	err := m.db.Select(&projects, "SELECT * FROM `projects` where workspace_id=?", ws)
+	if err != nil {
+		return projects, err
+	}
+
+	holidays := []model.Holiday{}
+	err = m.db.Select(&holidays, "SELECT * FROM `holidays` where workspace_id=?", ws)
+	if err != nil {
+		return projects, err
+	}
+	attachHolidays(projects, holidays)
+
+	checkIns := []model.CheckIn{}
+	err = m.db.Select(&checkIns, "SELECT * FROM `check_ins` where workspace_id=? ORDER BY id", ws)
+	attachCheckIns(projects, checkIns)
 	return projects, err
 }
 
@@ -89,6 +185,11 @@ func (m *DB) SelectProject(channelID string) (model.Project, error) {
 	if err != nil {
 		return c, err
 	}
+	c.Holidays, err = m.ListProjectHolidays(c.ChannelID)
+	if err != nil {
+		return c, err
+	}
+	c.CheckIns, err = m.ListProjectCheckIns(c.ChannelID)
 	return c, err
 }
 
@@ -99,6 +200,11 @@ func (m *DB) GetProject(id int64) (model.Project, error) {
 	if err != nil {
 		return c, err
 	}
+	c.Holidays, err = m.ListProjectHolidays(c.ChannelID)
+	if err != nil {
+		return c, err
+	}
+	c.CheckIns, err = m.ListProjectCheckIns(c.ChannelID)
 	return c, err
 }
 
@@ -107,3 +213,19 @@ func (m *DB) DeleteProject(id int64) error {
 	_, err := m.db.Exec("DELETE FROM `projects` WHERE id=?", id)
 	return err
 }
+
+//captureMode returns project capture mode, projects without one capture mentions
+func captureMode(ch model.Project) string {
+	if ch.CaptureMode == "" {
+		return model.CaptureMention
+	}
+	return ch.CaptureMode
+}
+
+//deadlineMode returns project deadline mode, projects without one have deadline in project timezone
+func deadlineMode(ch model.Project) string {
+	if ch.DeadlineMode == "" {
+		return model.DeadlineProject
+	}
+	return ch.DeadlineMode
+}
@@ -21,6 +21,7 @@ func TestCreateProject(t *testing.T) {
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, "foo", ch.WorkspaceID)
+	assert.Equal(t, model.DefaultBlockerEscalationDays, ch.BlockerEscalationDays)
 
 	assert.NoError(t, db.DeleteProject(ch.ID))
 }
@@ -66,5 +67,59 @@ func TestUpdateProjects(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "10:00", ch.Deadline)
 
+	ch.CaptureMode = model.CaptureThread
+	ch.ThreadTS = "1564640000.000100"
+	ch.ThreadDate = "2019-08-01"
+	_, err = db.UpdateProject(ch)
+	assert.NoError(t, err)
+
+	ch, err = db.SelectProject("bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, model.CaptureThread, ch.CaptureMode)
+	assert.Equal(t, "1564640000.000100", ch.ThreadTS)
+	assert.Equal(t, "2019-08-01", ch.ThreadDate)
+
+	assert.Equal(t, 0, len(ch.EscalationPolicy))
+	ch.EscalationPolicy = model.EscalationPolicy{
+		{OffsetMinutes: 0, Audience: model.AudienceUser},
+		{OffsetMinutes: 30, Audience: model.AudiencePM, Message: "{{.Users}} missed standup"},
+	}
+	_, err = db.UpdateProject(ch)
+	assert.NoError(t, err)
+
+	ch, err = db.SelectProject("bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ch.EscalationPolicy))
+	assert.Equal(t, model.AudiencePM, ch.EscalationPolicy[1].Audience)
+	assert.Equal(t, "{{.Users}} missed standup", ch.EscalationPolicy[1].Message)
+
+	assert.Equal(t, model.DeadlineProject, ch.DeadlineMode)
+	ch.DeadlineMode = model.DeadlineUser
+	_, err = db.UpdateProject(ch)
+	assert.NoError(t, err)
+
+	ch, err = db.SelectProject("bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, model.DeadlineUser, ch.DeadlineMode)
+
+	ch.ReportingChannel = "client-reports"
+	ch.ReportingTime = "9:00"
+	ch.WeeklyReportDay = "friday"
+	ch.DailyReportsDisabled = true
+	_, err = db.UpdateProject(ch)
+	assert.NoError(t, err)
+
+	ch, err = db.SelectProject("bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, "client-reports", ch.ReportingChannel)
+	assert.Equal(t, "9:00", ch.ReportingTime)
+	assert.Equal(t, "friday", ch.WeeklyReportDay)
+	assert.Equal(t, true, ch.DailyReportsDisabled)
+	assert.Equal(t, false, ch.WeeklyReportsDisabled)
+
+	ch.CaptureMode = "reply"
+	_, err = db.UpdateProject(ch)
+	assert.Error(t, err)
+
 	assert.NoError(t, db.DeleteProject(ch.ID))
 }
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateCheckIn creates check-in entry in database
+func (m *DB) CreateCheckIn(c model.CheckIn) (model.CheckIn, error) {
+	err := c.Validate()
+	if err != nil {
+		return c, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO check_ins (
+			created_at,
+			workspace_id, 
+			channel_id, 
+			name, 
+			deadline, 
+			questions
+		) VALUES (?, ?, ?, ?, ?, ?)`,
+		c.CreatedAt,
+		c.WorkspaceID,
+		c.ChannelID,
+		c.Name,
+		c.Deadline,
+		c.Questions,
+	)
+	if err != nil {
+		return c, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return c, err
+	}
+	c.ID = id
+
+	return c, nil
+}
+
+// UpdateCheckIn updates check-in entry in database
+func (m *DB) UpdateCheckIn(c model.CheckIn) (model.CheckIn, error) {
+	err := c.Validate()
+	if err != nil {
+		return c, err
+	}
+
+	_, err = m.db.Exec(
+		"UPDATE `check_ins` SET name=?, deadline=?, questions=? WHERE id=?",
+		c.Name, c.Deadline, c.Questions, c.ID,
+	)
+	return c, err
+}
+
+// GetCheckIn returns check-in by its ID
+func (m *DB) GetCheckIn(id int64) (model.CheckIn, error) {
+	var c model.CheckIn
+	err := m.db.Get(&c, "SELECT * FROM `check_ins` WHERE id=?", id)
+	return c, err
+}
+
+// ListProjectCheckIns returns named check-ins of the project in the order they were created
+func (m *DB) ListProjectCheckIns(channelID string) ([]model.CheckIn, error) {
+	items := []model.CheckIn{}
+	err := m.db.Select(&items, "SELECT * FROM `check_ins` WHERE channel_id=? ORDER BY id", channelID)
+	return items, err
+}
+
+// DeleteCheckIn deletes check-in entry from database
+func (m *DB) DeleteCheckIn(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `check_ins` WHERE id=?", id)
+	return err
+}
+
+//attachCheckIns fills named check-ins of the projects with check-ins given
+func attachCheckIns(projects []model.Project, checkIns []model.CheckIn) {
+	for i := range projects {
+		for _, checkIn := range checkIns {
+			if checkIn.ChannelID == projects[i].ChannelID {
+				projects[i].CheckIns = append(projects[i].CheckIns, checkIn)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIns(t *testing.T) {
+	_, err := db.CreateCheckIn(model.CheckIn{})
+	assert.Error(t, err)
+
+	project, err := db.CreateProject(model.Project{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelName: "checkins",
+		ChannelID:   "checkins12",
+		Deadline:    "10am",
+	})
+	require.NoError(t, err)
+
+	evening, err := db.CreateCheckIn(model.CheckIn{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "checkins12",
+		Name:        "evening",
+		Deadline:    "6pm",
+		Questions:   "What did you ship today?",
+	})
+	require.NoError(t, err)
+
+	_, err = db.CreateCheckIn(evening)
+	assert.Error(t, err)
+
+	evening.Deadline = "7pm"
+	_, err = db.UpdateCheckIn(evening)
+	require.NoError(t, err)
+
+	evening, err = db.GetCheckIn(evening.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "7pm", evening.Deadline)
+
+	loaded, err := db.SelectProject("checkins12")
+	require.NoError(t, err)
+	assert.Equal(t, []model.CheckIn{evening}, loaded.CheckIns)
+
+	projects, err := db.ListWorkspaceProjects("foo")
+	require.NoError(t, err)
+	for _, p := range projects {
+		if p.ChannelID == "checkins12" {
+			assert.Equal(t, 1, len(p.CheckIns))
+		}
+	}
+
+	assert.NoError(t, db.DeleteCheckIn(evening.ID))
+	checkIns, err := db.ListProjectCheckIns("checkins12")
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(checkIns))
+
+	assert.NoError(t, db.DeleteProject(project.ID))
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateConversation creates standup conversation entry in database
+func (m *DB) CreateConversation(c model.StandupConversation) (model.StandupConversation, error) {
+	err := c.Validate()
+	if err != nil {
+		return c, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO standup_conversations (
+			created_at,
+			workspace_id, 
+			channel_id, 
+			user_id, 
+			step, 
+			done, 
+			planned, 
+			blockers
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.CreatedAt,
+		c.WorkspaceID,
+		c.ChannelID,
+		c.UserID,
+		c.Step,
+		c.Done,
+		c.Planned,
+		c.Blockers,
+	)
+	if err != nil {
+		return c, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return c, err
+	}
+	c.ID = id
+
+	return c, nil
+}
+
+// UpdateConversation saves conversation answers and its current step
+func (m *DB) UpdateConversation(c model.StandupConversation) (model.StandupConversation, error) {
+	err := c.Validate()
+	if err != nil {
+		return c, err
+	}
+
+	_, err = m.db.Exec(
+		"UPDATE `standup_conversations` SET step=?, done=?, planned=?, blockers=? WHERE id=?",
+		c.Step, c.Done, c.Planned, c.Blockers, c.ID,
+	)
+	return c, err
+}
+
+// SelectActiveConversation returns the oldest user conversation in workspace
+func (m *DB) SelectActiveConversation(workspaceID, userID string) (model.StandupConversation, error) {
+	var c model.StandupConversation
+	err := m.db.Get(&c,
+		`select * from standup_conversations 
+		where workspace_id=? and user_id=? 
+		order by id limit 1`,
+		workspaceID, userID,
+	)
+	return c, err
+}
+
+// SelectConversation returns user conversation about particular channel standup
+func (m *DB) SelectConversation(userID, channelID string) (model.StandupConversation, error) {
+	var c model.StandupConversation
+	err := m.db.Get(&c, "SELECT * FROM `standup_conversations` WHERE user_id=? AND channel_id=?", userID, channelID)
+	return c, err
+}
+
+// DeleteConversation deletes standup conversation entry from database
+func (m *DB) DeleteConversation(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `standup_conversations` WHERE id=?", id)
+	return err
+}
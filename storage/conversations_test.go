@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversations(t *testing.T) {
+	_, err := db.CreateConversation(model.StandupConversation{})
+	assert.Error(t, err)
+
+	first, err := db.CreateConversation(model.StandupConversation{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		UserID:      "bar",
+	})
+	require.NoError(t, err)
+
+	second, err := db.CreateConversation(model.StandupConversation{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar13",
+		UserID:      "bar",
+	})
+	require.NoError(t, err)
+
+	active, err := db.SelectActiveConversation("foo", "bar")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, active.ID)
+
+	active.Step = 1
+	active.Done = "fixed login"
+	_, err = db.UpdateConversation(active)
+	require.NoError(t, err)
+
+	c, err := db.SelectConversation("bar", "bar12")
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.Step)
+	assert.Equal(t, "fixed login", c.Done)
+
+	_, err = db.SelectConversation("bar", "bar14")
+	assert.Error(t, err)
+
+	assert.NoError(t, db.DeleteConversation(first.ID))
+
+	active, err = db.SelectActiveConversation("foo", "bar")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, active.ID)
+
+	assert.NoError(t, db.DeleteConversation(second.ID))
+
+	_, err = db.SelectActiveConversation("foo", "bar")
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateExcuse creates excuse entry in database
+func (m *DB) CreateExcuse(e model.Excuse) (model.Excuse, error) {
+	err := e.Validate()
+	if err != nil {
+		return e, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO excuses (
+			created_at,
+			workspace_id, 
+			channel_id, 
+			user_id, 
+			date, 
+			kind, 
+			reason, 
+			until
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.CreatedAt,
+		e.WorkspaceID,
+		e.ChannelID,
+		e.UserID,
+		e.Date,
+		e.Kind,
+		e.Reason,
+		e.Until,
+	)
+	if err != nil {
+		return e, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return e, err
+	}
+	e.ID = id
+
+	return e, nil
+}
+
+// ListProjectExcuses returns skips and snoozes of project standupers for the date, the latest first
+func (m *DB) ListProjectExcuses(channelID, date string) ([]model.Excuse, error) {
+	items := []model.Excuse{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `excuses` WHERE channel_id=? AND date=? ORDER BY created_at DESC, id DESC",
+		channelID, date,
+	)
+	return items, err
+}
+
+// ListEndingSnoozes returns project snoozes that end from the first moment and before the second one
+func (m *DB) ListEndingSnoozes(channelID string, from, to int64) ([]model.Excuse, error) {
+	items := []model.Excuse{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `excuses` WHERE channel_id=? AND kind=? AND until>=? AND until<?",
+		channelID, model.ExcuseSnooze, from, to,
+	)
+	return items, err
+}
+
+// DeleteExcuse deletes excuse entry from database
+func (m *DB) DeleteExcuse(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `excuses` WHERE id=?", id)
+	return err
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcuses(t *testing.T) {
+	_, err := db.CreateExcuse(model.Excuse{})
+	assert.Error(t, err)
+
+	skip, err := db.CreateExcuse(model.Excuse{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		UserID:      "bar",
+		Date:        "2019-09-02",
+		Kind:        model.ExcuseSkip,
+		Reason:      "workshop",
+	})
+	require.NoError(t, err)
+
+	snooze, err := db.CreateExcuse(model.Excuse{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		UserID:      "baz",
+		Date:        "2019-09-02",
+		Kind:        model.ExcuseSnooze,
+		Until:       time.Now().Add(30 * time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+
+	excuses, err := db.ListProjectExcuses("bar12", "2019-09-02")
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(excuses))
+	assert.Equal(t, snooze.ID, excuses[0].ID)
+	assert.Equal(t, "workshop", excuses[1].Reason)
+
+	excuses, err = db.ListProjectExcuses("bar12", "2019-09-03")
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(excuses))
+
+	assert.NoError(t, db.DeleteExcuse(skip.ID))
+	assert.NoError(t, db.DeleteExcuse(snooze.ID))
+}
+
+func TestListEndingSnoozes(t *testing.T) {
+	until := time.Now().Add(30 * time.Minute).Unix()
+	snooze, err := db.CreateExcuse(model.Excuse{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		UserID:      "baz",
+		Date:        "2019-09-02",
+		Kind:        model.ExcuseSnooze,
+		Until:       until,
+	})
+	require.NoError(t, err)
+
+	snoozes, err := db.ListEndingSnoozes("bar12", until, until+60)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(snoozes))
+
+	snoozes, err = db.ListEndingSnoozes("bar12", until+1, until+60)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(snoozes))
+
+	assert.NoError(t, db.DeleteExcuse(snooze.ID))
+}
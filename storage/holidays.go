@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateHoliday creates holiday entry in database
+func (m *DB) CreateHoliday(h model.Holiday) (model.Holiday, error) {
+	err := h.Validate()
+	if err != nil {
+		return h, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO holidays (
+			created_at,
+			workspace_id, 
+			channel_id, 
+			date, 
+			name
+		) VALUES (?, ?, ?, ?, ?)`,
+		h.CreatedAt,
+		h.WorkspaceID,
+		h.ChannelID,
+		h.Date,
+		h.Name,
+	)
+	if err != nil {
+		return h, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return h, err
+	}
+	h.ID = id
+
+	return h, nil
+}
+
+// GetHoliday returns holiday by its ID
+func (m *DB) GetHoliday(id int64) (model.Holiday, error) {
+	var h model.Holiday
+	err := m.db.Get(&h, "SELECT * FROM `holidays` WHERE id=?", id)
+	return h, err
+}
+
+// ListProjectHolidays returns holiday calendar of the project ordered by date
+func (m *DB) ListProjectHolidays(channelID string) ([]model.Holiday, error) {
+	items := []model.Holiday{}
+	err := m.db.Select(&items, "SELECT * FROM `holidays` WHERE channel_id=? ORDER BY date", channelID)
+	return items, err
+}
+
+// DeleteHoliday deletes holiday entry from database
+func (m *DB) DeleteHoliday(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `holidays` WHERE id=?", id)
+	return err
+}
+
+//attachHolidays fills holiday calendars of the projects with holidays given
+func attachHolidays(projects []model.Project, holidays []model.Holiday) {
+	for i := range projects {
+		for _, holiday := range holidays {
+			if holiday.ChannelID == projects[i].ChannelID {
+				projects[i].Holidays = append(projects[i].Holidays, holiday)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolidays(t *testing.T) {
+	_, err := db.CreateHoliday(model.Holiday{})
+	assert.Error(t, err)
+
+	ch, err := db.CreateProject(model.Project{
+		WorkspaceID: "foo",
+		ChannelName: "bar",
+		ChannelID:   "bar12",
+	})
+	require.NoError(t, err)
+
+	h, err := db.CreateHoliday(model.Holiday{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		Date:        "2019-08-31",
+		Name:        "Independence Day",
+	})
+	require.NoError(t, err)
+
+	_, err = db.CreateHoliday(model.Holiday{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		Date:        "2019-08-31",
+	})
+	assert.Error(t, err)
+
+	holidays, err := db.ListProjectHolidays("bar12")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(holidays))
+	assert.Equal(t, "Independence Day", holidays[0].Name)
+
+	ch, err = db.SelectProject("bar12")
+	require.NoError(t, err)
+	assert.Equal(t, true, ch.IsHoliday("2019-08-31"))
+
+	ch, err = db.GetProject(ch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, true, ch.IsHoliday("2019-08-31"))
+
+	projects, err := db.ListWorkspaceProjects("foo")
+	require.NoError(t, err)
+	for _, project := range projects {
+		if project.ID == ch.ID {
+			assert.Equal(t, true, project.IsHoliday("2019-08-31"))
+		}
+	}
+
+	_, err = db.GetHoliday(h.ID)
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.DeleteHoliday(h.ID))
+	_, err = db.GetHoliday(h.ID)
+	assert.Error(t, err)
+
+	assert.NoError(t, db.DeleteProject(ch.ID))
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateJob creates job entry in database. Jobs are unique by workspace, kind, channel
+// and run time, the existing job is kept and zero ID is returned for a duplicate
+func (m *DB) CreateJob(j model.Job) (model.Job, error) {
+	err := j.Validate()
+	if err != nil {
+		return j, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT IGNORE INTO jobs (
+			created_at,
+			workspace_id, 
+			channel_id, 
+			kind, 
+			run_at, 
+			status, 
+			error
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		j.CreatedAt,
+		j.WorkspaceID,
+		j.ChannelID,
+		j.Kind,
+		j.RunAt,
+		j.Status,
+		j.Error,
+	)
+	if err != nil {
+		return j, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return j, err
+	}
+	j.ID = id
+
+	return j, nil
+}
+
+// ClaimJob marks pending job as running, only one caller can claim a job
+func (m *DB) ClaimJob(id, startedAt int64) (bool, error) {
+	res, err := m.db.Exec(
+		"UPDATE `jobs` SET status=?, started_at=? WHERE id=? AND status=?",
+		model.JobRunning, startedAt, id, model.JobPending,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected == 1, err
+}
+
+// FinishJob records job status, finish time and error
+func (m *DB) FinishJob(j model.Job) (model.Job, error) {
+	err := j.Validate()
+	if err != nil {
+		return j, err
+	}
+
+	_, err = m.db.Exec(
+		"UPDATE `jobs` SET status=?, finished_at=?, error=? WHERE id=?",
+		j.Status, j.FinishedAt, j.Error, j.ID,
+	)
+	return j, err
+}
+
+// GetJob returns job by its ID
+func (m *DB) GetJob(id int64) (model.Job, error) {
+	var j model.Job
+	err := m.db.Get(&j, "SELECT * FROM `jobs` WHERE id=?", id)
+	return j, err
+}
+
+// ListJobsSince returns workspace jobs of all statuses that run at the moment given or later
+func (m *DB) ListJobsSince(workspaceID string, since int64) ([]model.Job, error) {
+	items := []model.Job{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `jobs` WHERE workspace_id=? AND run_at>=? ORDER BY run_at",
+		workspaceID, since,
+	)
+	return items, err
+}
+
+// ListPendingJobs returns workspace jobs that have not run yet, the nearest first
+func (m *DB) ListPendingJobs(workspaceID string) ([]model.Job, error) {
+	items := []model.Job{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `jobs` WHERE workspace_id=? AND status=? ORDER BY run_at",
+		workspaceID, model.JobPending,
+	)
+	return items, err
+}
+
+// ListDueJobs returns pending workspace jobs which run time has come
+func (m *DB) ListDueJobs(workspaceID string, now int64) ([]model.Job, error) {
+	items := []model.Job{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `jobs` WHERE workspace_id=? AND status=? AND run_at<=? ORDER BY run_at",
+		workspaceID, model.JobPending, now,
+	)
+	return items, err
+}
+
+// DeleteJob deletes job entry from database
+func (m *DB) DeleteJob(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `jobs` WHERE id=?", id)
+	return err
+}
+
+// DeleteFinishedJobs deletes workspace jobs that are not pending and ran before the moment given
+func (m *DB) DeleteFinishedJobs(workspaceID string, before int64) error {
+	_, err := m.db.Exec(
+		"DELETE FROM `jobs` WHERE workspace_id=? AND status<>? AND run_at<?",
+		workspaceID, model.JobPending, before,
+	)
+	return err
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobs(t *testing.T) {
+	_, err := db.CreateJob(model.Job{})
+	assert.Error(t, err)
+
+	runAt := time.Now().Add(-time.Minute).Unix()
+	job, err := db.CreateJob(model.Job{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		ChannelID:   "bar12",
+		Kind:        model.JobEscalation,
+		RunAt:       runAt,
+		Status:      model.JobPending,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), job.ID)
+
+	duplicate, err := db.CreateJob(job)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), duplicate.ID)
+
+	jobs, err := db.ListPendingJobs("foo")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(jobs))
+
+	jobs, err = db.ListDueJobs("foo", runAt-1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(jobs))
+
+	jobs, err = db.ListDueJobs("foo", time.Now().Unix())
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(jobs))
+
+	claimed, err := db.ClaimJob(job.ID, time.Now().Unix())
+	require.NoError(t, err)
+	assert.Equal(t, true, claimed)
+
+	claimed, err = db.ClaimJob(job.ID, time.Now().Unix())
+	require.NoError(t, err)
+	assert.Equal(t, false, claimed)
+
+	job.Status = model.JobDone
+	job.FinishedAt = time.Now().Unix()
+	_, err = db.FinishJob(job)
+	require.NoError(t, err)
+
+	job, err = db.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobDone, job.Status)
+
+	jobs, err = db.ListJobsSince("foo", runAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(jobs))
+
+	jobs, err = db.ListPendingJobs("foo")
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(jobs))
+
+	assert.NoError(t, db.DeleteFinishedJobs("foo", runAt+1))
+	_, err = db.GetJob(job.ID)
+	assert.Error(t, err)
+}
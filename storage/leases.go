@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/maddevsio/comedian/model"
+)
+
+// AcquireLease takes the lease for the holder until expiresAt if the lease is free, expired
+// or already belongs to the holder. Returns if the holder has the lease now
+func (m *DB) AcquireLease(name, holder string, now, expiresAt int64) (bool, error) {
+	if name == "" || holder == "" {
+		return false, errors.New("lease name and holder cannot be empty")
+	}
+
+	_, err := m.db.Exec(
+		"INSERT IGNORE INTO `leases` (name, holder, acquired_at, expires_at) VALUES (?, ?, ?, ?)",
+		name, holder, now, expiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = m.db.Exec(
+		"UPDATE `leases` SET acquired_at=IF(holder=?, acquired_at, ?), holder=?, expires_at=? WHERE name=? AND (holder=? OR expires_at<?)",
+		holder, now, holder, expiresAt, name, holder, now,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	lease, err := m.GetLease(name)
+	if err != nil {
+		return false, err
+	}
+	return lease.Holder == holder, nil
+}
+
+// GetLease returns lease by its name
+func (m *DB) GetLease(name string) (model.Lease, error) {
+	var l model.Lease
+	err := m.db.Get(&l, "SELECT * FROM `leases` WHERE name=?", name)
+	return l, err
+}
+
+// ReleaseLease frees the lease if it belongs to the holder
+func (m *DB) ReleaseLease(name, holder string) error {
+	_, err := m.db.Exec("DELETE FROM `leases` WHERE name=? AND holder=?", name, holder)
+	return err
+}
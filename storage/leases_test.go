@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeases(t *testing.T) {
+	_, err := db.AcquireLease("", "replica1", 0, 0)
+	assert.Error(t, err)
+
+	now := time.Now().Unix()
+
+	acquired, err := db.AcquireLease("scheduler/foo", "replica1", now, now+180)
+	require.NoError(t, err)
+	assert.Equal(t, true, acquired)
+
+	//lease is not given away before it expires
+	acquired, err = db.AcquireLease("scheduler/foo", "replica2", now+60, now+240)
+	require.NoError(t, err)
+	assert.Equal(t, false, acquired)
+
+	//holder renews the lease
+	acquired, err = db.AcquireLease("scheduler/foo", "replica1", now+60, now+240)
+	require.NoError(t, err)
+	assert.Equal(t, true, acquired)
+
+	lease, err := db.GetLease("scheduler/foo")
+	require.NoError(t, err)
+	assert.Equal(t, now, lease.AcquiredAt)
+	assert.Equal(t, now+240, lease.ExpiresAt)
+
+	//expired lease goes to another replica
+	acquired, err = db.AcquireLease("scheduler/foo", "replica2", now+300, now+480)
+	require.NoError(t, err)
+	assert.Equal(t, true, acquired)
+
+	//only holder releases the lease
+	assert.NoError(t, db.ReleaseLease("scheduler/foo", "replica1"))
+	_, err = db.GetLease("scheduler/foo")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.ReleaseLease("scheduler/foo", "replica2"))
+	_, err = db.GetLease("scheduler/foo")
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateNotificationPreference creates notification preference entry in database
+func (m *DB) CreateNotificationPreference(p model.NotificationPreference) (model.NotificationPreference, error) {
+	err := p.Validate()
+	if err != nil {
+		return p, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO notification_preferences (
+			created_at,
+			workspace_id, 
+			user_id, 
+			delivery, 
+			heads_up_minutes
+		) VALUES (?, ?, ?, ?, ?)`,
+		p.CreatedAt,
+		p.WorkspaceID,
+		p.UserID,
+		p.Delivery,
+		p.HeadsUpMinutes,
+	)
+	if err != nil {
+		return p, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return p, err
+	}
+	p.ID = id
+
+	return p, nil
+}
+
+// UpdateNotificationPreference updates notification preference entry in database
+func (m *DB) UpdateNotificationPreference(p model.NotificationPreference) (model.NotificationPreference, error) {
+	err := p.Validate()
+	if err != nil {
+		return p, err
+	}
+
+	_, err = m.db.Exec(
+		"UPDATE `notification_preferences` SET delivery=?, heads_up_minutes=? WHERE id=?",
+		p.Delivery, p.HeadsUpMinutes, p.ID,
+	)
+	return p, err
+}
+
+// GetNotificationPreference returns notification preference by its ID
+func (m *DB) GetNotificationPreference(id int64) (model.NotificationPreference, error) {
+	var p model.NotificationPreference
+	err := m.db.Get(&p, "SELECT * FROM `notification_preferences` WHERE id=?", id)
+	return p, err
+}
+
+// SelectNotificationPreference returns notification preference of the user in workspace
+func (m *DB) SelectNotificationPreference(workspaceID, userID string) (model.NotificationPreference, error) {
+	var p model.NotificationPreference
+	err := m.db.Get(&p,
+		"SELECT * FROM `notification_preferences` WHERE workspace_id=? AND user_id=?",
+		workspaceID, userID,
+	)
+	return p, err
+}
+
+// ListNotificationPreferences returns notification preferences of workspace users
+func (m *DB) ListNotificationPreferences(workspaceID string) ([]model.NotificationPreference, error) {
+	items := []model.NotificationPreference{}
+	err := m.db.Select(&items, "SELECT * FROM `notification_preferences` WHERE workspace_id=?", workspaceID)
+	return items, err
+}
+
+// DeleteNotificationPreference deletes notification preference entry from database
+func (m *DB) DeleteNotificationPreference(id int64) error {
+	_, err := m.db.Exec("DELETE FROM `notification_preferences` WHERE id=?", id)
+	return err
+}
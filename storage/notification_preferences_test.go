@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	_, err := db.CreateNotificationPreference(model.NotificationPreference{})
+	assert.Error(t, err)
+
+	p, err := db.CreateNotificationPreference(model.NotificationPreference{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		Delivery:    model.DeliveryChannel,
+	})
+	require.NoError(t, err)
+
+	_, err = db.CreateNotificationPreference(model.NotificationPreference{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		Delivery:    model.DeliveryDM,
+	})
+	assert.Error(t, err)
+
+	p.Delivery = model.DeliveryDM
+	p.HeadsUpMinutes = 15
+	_, err = db.UpdateNotificationPreference(p)
+	require.NoError(t, err)
+
+	selected, err := db.SelectNotificationPreference("foo", "bar")
+	require.NoError(t, err)
+	assert.Equal(t, model.DeliveryDM, selected.Delivery)
+	assert.Equal(t, int64(15), selected.HeadsUpMinutes)
+
+	_, err = db.SelectNotificationPreference("foo", "baz")
+	assert.Error(t, err)
+
+	got, err := db.GetNotificationPreference(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", got.UserID)
+
+	preferences, err := db.ListNotificationPreferences("foo")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(preferences))
+
+	assert.NoError(t, db.DeleteNotificationPreference(p.ID))
+
+	_, err = db.GetNotificationPreference(p.ID)
+	assert.Error(t, err)
+}
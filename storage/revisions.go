@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"github.com/maddevsio/comedian/model"
+)
+
+// CreateStandupRevision creates standup revision entry in database
+func (m *DB) CreateStandupRevision(r model.StandupRevision) (model.StandupRevision, error) {
+	err := r.Validate()
+	if err != nil {
+		return r, err
+	}
+
+	res, err := m.db.Exec(
+		`INSERT INTO standup_revisions (
+			created_at,
+			standup_id, 
+			action, 
+			comment, 
+			done, 
+			planned, 
+			blockers
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		r.CreatedAt,
+		r.StandupID,
+		r.Action,
+		r.Comment,
+		r.Done,
+		r.Planned,
+		r.Blockers,
+	)
+	if err != nil {
+		return r, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return r, err
+	}
+	r.ID = id
+
+	return r, nil
+}
+
+// ListStandupRevisions returns standup revisions starting from the earliest one
+func (m *DB) ListStandupRevisions(standupID int64) ([]model.StandupRevision, error) {
+	items := []model.StandupRevision{}
+	err := m.db.Select(&items, "SELECT * FROM `standup_revisions` WHERE standup_id=? order by id", standupID)
+	return items, err
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maddevsio/comedian/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandupRevisions(t *testing.T) {
+	_, err := db.CreateStandupRevision(model.StandupRevision{})
+	assert.Error(t, err)
+
+	st, err := db.CreateStandup(model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		ChannelID:   "bar12",
+		Comment:     "original",
+		MessageTS:   "12345",
+	})
+	assert.NoError(t, err)
+
+	_, err = db.CreateStandupRevision(st.Revision(model.RevisionEdited))
+	assert.NoError(t, err)
+
+	st.Comment = "edited"
+	st, err = db.UpdateStandup(st)
+	assert.NoError(t, err)
+
+	_, err = db.CreateStandupRevision(st.Revision(model.RevisionRetracted))
+	assert.NoError(t, err)
+	assert.NoError(t, db.RetractStandup(st.ID, time.Now().Unix()))
+
+	revisions, err := db.ListStandupRevisions(st.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(revisions))
+	assert.Equal(t, "original", revisions[0].Comment)
+	assert.Equal(t, model.RevisionEdited, revisions[0].Action)
+	assert.Equal(t, "edited", revisions[1].Comment)
+	assert.Equal(t, model.RevisionRetracted, revisions[1].Action)
+
+	st, err = db.GetStandup(st.ID)
+	assert.NoError(t, err)
+	assert.True(t, st.Retracted())
+
+	_, err = db.SelectLatestStandupByUser("bar", "bar12")
+	assert.Error(t, err)
+
+	assert.NoError(t, db.DeleteStandup(st.ID))
+
+	revisions, err = db.ListStandupRevisions(st.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(revisions))
+}
@@ -18,8 +18,9 @@ func (m *DB) CreateStanduper(s model.Standuper) (model.Standuper, error) {
 			channel_id, 
 			role, 
 			real_name, 
-			channel_name
-		) VALUES (?,?,?,?,?,?,?)`,
+			channel_name,
+			tz
+		) VALUES (?,?,?,?,?,?,?,?)`,
 		s.CreatedAt,
 		s.WorkspaceID,
 		s.UserID,
@@ -27,6 +28,7 @@ func (m *DB) CreateStanduper(s model.Standuper) (model.Standuper, error) {
 		s.Role,
 		s.RealName,
 		s.ChannelName,
+		s.TZ,
 	)
 	if err != nil {
 		return s, err
@@ -47,8 +49,8 @@ func (m *DB) UpdateStanduper(st model.Standuper) (model.Standuper, error) {
 		return st, err
 	}
 	_, err = m.db.Exec(
-		"UPDATE `standupers` SET role=? WHERE id=?",
-		st.Role, st.ID,
+		"UPDATE `standupers` SET role=?, tz=? WHERE id=?",
+		st.Role, st.TZ, st.ID,
 	)
 	if err != nil {
 		return st, err
@@ -93,5 +93,14 @@ func TestUpdateStanduper(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "developer", s.Role)
 
+	s.TZ = "Europe/Berlin"
+	s, err = db.UpdateStanduper(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", s.TZ)
+
+	s.TZ = "Mars/Olympus"
+	_, err = db.UpdateStanduper(s)
+	assert.Error(t, err)
+
 	assert.NoError(t, db.DeleteStanduper(s.ID))
 }
@@ -18,14 +18,30 @@ func (m *DB) CreateStandup(s model.Standup) (model.Standup, error) {
 			channel_id, 
 			user_id, 
 			comment, 
-			message_ts
-		) VALUES (?, ?, ?, ?, ?, ?)`,
+			done, 
+			planned, 
+			blockers, 
+			message_ts,
+			deadline_at,
+			late_minutes,
+			backfilled_at,
+			standup_date,
+			check_in
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		s.CreatedAt,
 		s.WorkspaceID,
 		s.ChannelID,
 		s.UserID,
 		s.Comment,
+		s.Done,
+		s.Planned,
+		s.Blockers,
 		s.MessageTS,
+		s.DeadlineAt,
+		s.LateMinutes,
+		s.BackfilledAt,
+		s.StandupDate,
+		s.CheckIn,
 	)
 	if err != nil {
 		return s, err
@@ -47,8 +63,8 @@ func (m *DB) UpdateStandup(s model.Standup) (model.Standup, error) {
 	}
 
 	_, err = m.db.Exec(
-		"UPDATE `standups` SET comment=?, message_ts=? WHERE id=?",
-		s.Comment, s.MessageTS, s.ID,
+		"UPDATE `standups` SET comment=?, done=?, planned=?, blockers=?, message_ts=?, standup_date=? WHERE id=?",
+		s.Comment, s.Done, s.Planned, s.Blockers, s.MessageTS, s.StandupDate, s.ID,
 	)
 	if err != nil {
 		return s, err
@@ -92,13 +108,14 @@ func (m *DB) SelectStandupByMessageTS(messageTS string) (model.Standup, error) {
 	return s, nil
 }
 
-// SelectLatestStandupByUser selects standup entry from database filtered by user
+// SelectLatestStandupByUser selects standup entry from database filtered by user.
+// Standups are ordered by standup date since backfilled ones are created for past days
 func (m *DB) SelectLatestStandupByUser(userID, channelID string) (model.Standup, error) {
 	var s model.Standup
 	err := m.db.Get(&s,
 		`select * from standups 
-		where user_id=? and channel_id=? 
-		order by id desc limit 1`,
+		where user_id=? and channel_id=? and retracted_at=0 
+		order by standup_date desc, created_at desc, id desc limit 1`,
 		userID, channelID,
 	)
 	if err != nil {
@@ -107,18 +124,28 @@ func (m *DB) SelectLatestStandupByUser(userID, channelID string) (model.Standup,
 	return s, nil
 }
 
-// GetStandupForPeriod selects standup entry from database filtered by user
-func (m *DB) GetStandupForPeriod(userID, channelID string, timeFrom, timeTo int64) (*model.Standup, error) {
+// ListStanduperStandups returns standups user submitted in the channel
+func (m *DB) ListStanduperStandups(userID, channelID string) ([]model.Standup, error) {
+	items := []model.Standup{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `standups` WHERE user_id=? AND channel_id=? ORDER BY id DESC",
+		userID, channelID,
+	)
+	return items, err
+}
+
+// GetStandupForDate selects standup user submitted for the check-in on the date in YYYY-MM-DD format.
+// Retracted standup is returned only if there is no other standup for the date
+func (m *DB) GetStandupForDate(userID, channelID, date, checkIn string) (*model.Standup, error) {
 	s := &model.Standup{}
 	err := m.db.Get(s,
 		`select * from standups 
-		where user_id=? and channel_id=? 
-		and created_at BETWEEN ? AND ? 
-		limit 1`,
+		where user_id=? and channel_id=? and standup_date=? and check_in=? 
+		order by retracted_at, id desc limit 1`,
 		userID,
 		channelID,
-		timeFrom,
-		timeTo,
+		date,
+		checkIn,
 	)
 	if err != nil {
 		return s, err
@@ -126,8 +153,29 @@ func (m *DB) GetStandupForPeriod(userID, channelID string, timeFrom, timeTo int6
 	return s, nil
 }
 
-// DeleteStandup deletes standup entry from database
+// ListStandupsForDate returns standups user submitted for the date in YYYY-MM-DD format
+// that are not retracted, one for every check-in the user answered
+func (m *DB) ListStandupsForDate(userID, channelID, date string) ([]model.Standup, error) {
+	items := []model.Standup{}
+	err := m.db.Select(&items,
+		"SELECT * FROM `standups` WHERE user_id=? AND channel_id=? AND standup_date=? AND retracted_at=0 ORDER BY id",
+		userID, channelID, date,
+	)
+	return items, err
+}
+
+// RetractStandup marks standup as retracted keeping it in database
+func (m *DB) RetractStandup(id int64, retractedAt int64) error {
+	_, err := m.db.Exec("UPDATE `standups` SET retracted_at=? WHERE id=?", retractedAt, id)
+	return err
+}
+
+// DeleteStandup deletes standup entry and its revisions from database
 func (m *DB) DeleteStandup(id int64) error {
 	_, err := m.db.Exec("DELETE FROM `standups` WHERE id=?", id)
+	if err != nil {
+		return err
+	}
+	_, err = m.db.Exec("DELETE FROM `standup_revisions` WHERE standup_id=?", id)
 	return err
 }
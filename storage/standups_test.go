@@ -59,14 +59,14 @@ func TestGetStandups(t *testing.T) {
 	_, err = db.GetStandup(st.ID)
 	assert.NoError(t, err)
 
-	res, err := db.GetStandupForPeriod("bar", "bar12", time.Now().Add(10*time.Second*(-1)).Unix(), time.Now().Add(10*time.Second).Unix())
+	res, err := db.GetStandupForDate("bar", "bar12", st.StandupDate, "")
 	assert.NoError(t, err)
 	assert.Equal(t, "12345", res.MessageTS)
 
-	_, err = db.GetStandupForPeriod("foo", "bar12", time.Now().Add(10*time.Second*(-1)).Unix(), time.Now().Add(10*time.Second).Unix())
+	_, err = db.GetStandupForDate("foo", "bar12", st.StandupDate, "")
 	assert.Error(t, err)
 
-	_, err = db.GetStandupForPeriod("foo", "bar12", time.Now().Add(10*time.Hour*(-1)).Unix(), time.Now().Add(10*time.Second*(-1)).Unix())
+	_, err = db.GetStandupForDate("bar", "bar12", "2000-01-01", "")
 	assert.Error(t, err)
 
 	assert.NoError(t, db.DeleteStandup(st.ID))
@@ -95,3 +95,114 @@ func TestUpdateStandup(t *testing.T) {
 
 	assert.NoError(t, db.DeleteStandup(st.ID))
 }
+
+func TestGetStandupForDate(t *testing.T) {
+
+	st, err := db.CreateStandup(model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		ChannelID:   "bar12",
+		MessageTS:   "12345",
+		StandupDate: "2019-09-02",
+	})
+	assert.NoError(t, err)
+
+	res, err := db.GetStandupForDate("bar", "bar12", "2019-09-02", "")
+	assert.NoError(t, err)
+	assert.Equal(t, st.ID, res.ID)
+
+	_, err = db.GetStandupForDate("bar", "bar12", "2019-09-03", "")
+	assert.Error(t, err)
+
+	st.StandupDate = "2019-09-03"
+	_, err = db.UpdateStandup(st)
+	assert.NoError(t, err)
+
+	res, err = db.GetStandupForDate("bar", "bar12", "2019-09-03", "")
+	assert.NoError(t, err)
+	assert.Equal(t, st.ID, res.ID)
+
+	evening, err := db.CreateStandup(model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		ChannelID:   "bar12",
+		MessageTS:   "12346",
+		StandupDate: "2019-09-03",
+		CheckIn:     "evening",
+	})
+	assert.NoError(t, err)
+
+	res, err = db.GetStandupForDate("bar", "bar12", "2019-09-03", "evening")
+	assert.NoError(t, err)
+	assert.Equal(t, evening.ID, res.ID)
+
+	standups, err := db.ListStandupsForDate("bar", "bar12", "2019-09-03")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(standups))
+
+	assert.NoError(t, db.DeleteStandup(evening.ID))
+	assert.NoError(t, db.DeleteStandup(st.ID))
+}
+
+func TestStandupLateness(t *testing.T) {
+
+	st, err := db.CreateStandup(model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		ChannelID:   "bar12",
+		MessageTS:   "12345",
+		DeadlineAt:  time.Now().Add(-15 * time.Minute).Unix(),
+		LateMinutes: 15,
+	})
+	assert.NoError(t, err)
+
+	standups, err := db.ListStanduperStandups("bar", "bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(standups))
+	assert.Equal(t, 15, standups[0].LateMinutes)
+	assert.Equal(t, true, standups[0].Late())
+
+	standups, err = db.ListStanduperStandups("foo", "bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(standups))
+
+	assert.NoError(t, db.DeleteStandup(st.ID))
+}
+
+func TestBackfilledStandup(t *testing.T) {
+
+	st, err := db.CreateStandup(model.Standup{
+		CreatedAt:   time.Now().Unix(),
+		WorkspaceID: "foo",
+		UserID:      "bar",
+		ChannelID:   "bar12",
+		MessageTS:   "12345",
+	})
+	assert.NoError(t, err)
+
+	backfilled, err := db.CreateStandup(model.Standup{
+		CreatedAt:    time.Now().AddDate(0, 0, -2).Unix(),
+		WorkspaceID:  "foo",
+		UserID:       "bar",
+		ChannelID:    "bar12",
+		MessageTS:    "12346",
+		BackfilledAt: time.Now().Unix(),
+		StandupDate:  time.Now().AddDate(0, 0, -2).Format("2006-01-02"),
+	})
+	assert.NoError(t, err)
+
+	latest, err := db.SelectLatestStandupByUser("bar", "bar12")
+	assert.NoError(t, err)
+	assert.Equal(t, st.ID, latest.ID)
+
+	res, err := db.GetStandupForDate("bar", "bar12", time.Now().AddDate(0, 0, -2).Format("2006-01-02"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, backfilled.ID, res.ID)
+	assert.Equal(t, true, res.Backfilled())
+
+	assert.NoError(t, db.DeleteStandup(st.ID))
+	assert.NoError(t, db.DeleteStandup(backfilled.ID))
+}
@@ -24,8 +24,10 @@ func (m *DB) CreateWorkspace(bs model.Workspace) (model.Workspace, error) {
 			projects_reports_enabled, 
 			reporting_channel, 
 			reporting_time, 
-			language
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			language,
+			report_sections,
+			scoring
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		bs.CreatedAt,
 		bs.NotifierInterval,
 		bs.MaxReminders,
@@ -38,6 +40,8 @@ func (m *DB) CreateWorkspace(bs model.Workspace) (model.Workspace, error) {
 		bs.ReportingChannel,
 		bs.ReportingTime,
 		bs.Language,
+		bs.ReportSections,
+		bs.Scoring,
 	)
 	if err != nil {
 		return bs, err
@@ -72,7 +76,9 @@ func (m *DB) UpdateWorkspace(settings model.Workspace) (model.Workspace, error)
 			projects_reports_enabled=?, 
 			reporting_channel=?, 
 			reporting_time=?, 
-			language=?
+			language=?,
+			report_sections=?,
+			scoring=?
 			where id=?`,
 		settings.NotifierInterval,
 		settings.MaxReminders,
@@ -85,6 +91,8 @@ func (m *DB) UpdateWorkspace(settings model.Workspace) (model.Workspace, error)
 		settings.ReportingChannel,
 		settings.ReportingTime,
 		settings.Language,
+		settings.ReportSections,
+		settings.Scoring,
 		settings.ID,
 	)
 	if err != nil {
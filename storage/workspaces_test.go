@@ -94,10 +94,23 @@ func TestUpdateAndDeleteWorkspace(t *testing.T) {
 	assert.Equal(t, "en_US", bot.Language)
 
 	bot.Language = "ru_RU"
+	bot.ReportSections = "standup,worklogs"
+	bot.Scoring = model.Scoring{Roles: map[string]model.ScoringRules{
+		"qa": {DailyWorklogs: model.Thresholds{{From: 0, Emoji: ":disappointed:"}, {From: 4, Emoji: ":wink:", Points: 1}}},
+	}}
 
 	bot, err = db.UpdateWorkspace(bot)
 	assert.NoError(t, err)
 	assert.Equal(t, "ru_RU", bot.Language)
 
+	bot, err = db.GetWorkspace(bot.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "standup,worklogs", bot.ReportSections)
+	assert.Equal(t, 4, bot.Scoring.Roles["qa"].DailyWorklogs[1].From)
+
+	bot.ReportSections = "karma"
+	_, err = db.UpdateWorkspace(bot)
+	assert.Error(t, err)
+
 	assert.NoError(t, db.DeleteWorkspace(bot.WorkspaceID))
 }